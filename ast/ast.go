@@ -87,7 +87,7 @@ type Comment struct {
 }
 
 func (c *Comment) Pos() token.Pos { return c.Slash }
-func (c *Comment) End() token.Pos { return token.Pos(int(c.Slash) + len(c.Text)) }
+func (c *Comment) End() token.Pos { return c.Slash + token.Pos(len(c.Text)) }
 
 // CommentGroup node represents a sequence of comments
 // with no other tokens and no empty lines between.
@@ -1,6 +1,12 @@
 package ast
 
-import "github.com/stable-lang/stlang/token"
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/stable-lang/stlang/literal"
+	"github.com/stable-lang/stlang/token"
+)
 
 // BadExpr node is a placeholder for an expression containing syntax errors
 // for which a correct expression node cannot be created.
@@ -29,6 +35,88 @@ type BasicLit struct {
 	ValuePos token.Pos   // literal position
 	Kind     token.Token // token.Int, token.Float, token.Char, or token.String
 	Value    string      // literal string; e.g. 42, 0x7f, 3.14, 'a', '\x7f', "foo" or `\m\n\o`
+
+	decoded *litValue // cached decoded value; or nil
+}
+
+// litValue is a decoded value of a BasicLit.
+type litValue struct {
+	kind token.Token // literal kind the value was decoded as
+	lit  string      // literal string the value was decoded from
+	val  any         // *big.Int, *big.Float, rune or string
+	err  error
+}
+
+// IntValue returns the value of a token.Int literal.
+// The result is cached and must not be modified.
+func (x *BasicLit) IntValue() (*big.Int, error) {
+	v, err := x.value(token.Int)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*big.Int), nil
+}
+
+// FloatValue returns the value of a token.Float literal.
+// The result is cached and must not be modified.
+func (x *BasicLit) FloatValue() (*big.Float, error) {
+	v, err := x.value(token.Float)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*big.Float), nil
+}
+
+// CharValue returns the value of a token.Char literal.
+func (x *BasicLit) CharValue() (rune, error) {
+	v, err := x.value(token.Char)
+	if err != nil {
+		return 0, err
+	}
+	return v.(rune), nil
+}
+
+// StringValue returns the unquoted value of a token.String literal.
+func (x *BasicLit) StringValue() (string, error) {
+	v, err := x.value(token.String)
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// value returns the decoded value of the literal, which must be of the given kind.
+// The value is decoded once and recomputed only if x.Kind or x.Value changes.
+// Like the rest of the AST, the cache is not safe for concurrent modification.
+func (x *BasicLit) value(kind token.Token) (any, error) {
+	if x.Kind != kind {
+		return nil, fmt.Errorf("%s literal %s is not %s", x.Kind, x.Value, kind)
+	}
+
+	if v := x.decoded; v != nil && v.kind == kind && v.lit == x.Value {
+		return v.val, v.err
+	}
+
+	v := &litValue{kind: kind, lit: x.Value}
+	switch kind {
+	case token.Int:
+		v.val, v.err = literal.ParseInt(x.Value)
+	case token.Float:
+		v.val, v.err = literal.ParseFloat(x.Value)
+	case token.Char:
+		v.val, v.err = literal.UnquoteChar(x.Value)
+	case token.String:
+		if x.Value != "" && x.Value[0] == '\'' {
+			v.err = literal.ErrSyntax // a char literal
+			break
+		}
+		v.val, v.err = literal.Unquote(x.Value)
+	}
+	if v.err != nil {
+		v.err = fmt.Errorf("invalid %s literal %s: %w", kind, x.Value, v.err)
+	}
+	x.decoded = v
+	return v.val, v.err
 }
 
 // CompositeLit node represents a composite literal.
@@ -125,6 +213,14 @@ type StarExpr struct {
 	X    Expr      // operand
 }
 
+// TypeAssertExpr node represents an expression followed by a type assertion.
+type TypeAssertExpr struct {
+	X          Expr      // expression
+	LeftParen  token.Pos // position of "("
+	Type       Expr      // asserted type; nil means type switch X.(typedef)
+	RightParen token.Pos // position of ")"
+}
+
 // UnaryExpr node represents a unary expression.
 // Unary "*" expressions are represented via StarExpr nodes.
 type UnaryExpr struct {
@@ -156,6 +252,12 @@ type MapType struct {
 	ValueType Expr
 }
 
+// OptionalType node represents a nullable type of the form Type "?".
+type OptionalType struct {
+	X        Expr      // underlying type
+	Question token.Pos // position of "?"
+}
+
 // SliceType node represents a slice type.
 type SliceType struct {
 	LeftBrack token.Pos // position of "["
@@ -177,32 +279,34 @@ func (x *CompositeLit) Pos() token.Pos {
 	}
 	return x.LeftBrace
 }
-func (x *FuncLit) Pos() token.Pos       { return x.Type.Pos() }
-func (x *BinaryExpr) Pos() token.Pos    { return x.X.Pos() }
-func (x *CallExpr) Pos() token.Pos      { return x.Fun.Pos() }
-func (x *Ellipsis) Pos() token.Pos      { return x.Ellipsis }
-func (x *IndexExpr) Pos() token.Pos     { return x.X.Pos() }
-func (x *IndexListExpr) Pos() token.Pos { return x.X.Pos() }
-func (x *KeyValueExpr) Pos() token.Pos  { return x.Key.Pos() }
-func (x *ParenExpr) Pos() token.Pos     { return x.LeftParen }
-func (x *SelectorExpr) Pos() token.Pos  { return x.X.Pos() }
-func (x *SliceExpr) Pos() token.Pos     { return x.X.Pos() }
-func (x *StarExpr) Pos() token.Pos      { return x.Star }
-func (x *UnaryExpr) Pos() token.Pos     { return x.OpPos }
-func (x *ArrayType) Pos() token.Pos     { return x.LeftBrack }
+func (x *FuncLit) Pos() token.Pos        { return x.Type.Pos() }
+func (x *BinaryExpr) Pos() token.Pos     { return x.X.Pos() }
+func (x *CallExpr) Pos() token.Pos       { return x.Fun.Pos() }
+func (x *Ellipsis) Pos() token.Pos       { return x.Ellipsis }
+func (x *IndexExpr) Pos() token.Pos      { return x.X.Pos() }
+func (x *IndexListExpr) Pos() token.Pos  { return x.X.Pos() }
+func (x *KeyValueExpr) Pos() token.Pos   { return x.Key.Pos() }
+func (x *ParenExpr) Pos() token.Pos      { return x.LeftParen }
+func (x *SelectorExpr) Pos() token.Pos   { return x.X.Pos() }
+func (x *SliceExpr) Pos() token.Pos      { return x.X.Pos() }
+func (x *StarExpr) Pos() token.Pos       { return x.Star }
+func (x *TypeAssertExpr) Pos() token.Pos { return x.X.Pos() }
+func (x *UnaryExpr) Pos() token.Pos      { return x.OpPos }
+func (x *ArrayType) Pos() token.Pos      { return x.LeftBrack }
 func (x *FuncType) Pos() token.Pos {
 	if x.Func.IsValid() || x.Params == nil { // see issue 3870
 		return x.Func
 	}
 	return x.Params.Pos() // interface method declarations have no "func" keyword
 }
-func (x *MapType) Pos() token.Pos    { return x.LeftBrack }
-func (x *SliceType) Pos() token.Pos  { return x.LeftBrack }
-func (x *StructType) Pos() token.Pos { return x.Struct }
+func (x *OptionalType) Pos() token.Pos { return x.X.Pos() }
+func (x *MapType) Pos() token.Pos      { return x.LeftBrack }
+func (x *SliceType) Pos() token.Pos    { return x.LeftBrack }
+func (x *StructType) Pos() token.Pos   { return x.Struct }
 
 func (x *BadExpr) End() token.Pos      { return x.To }
-func (x *Ident) End() token.Pos        { return token.Pos(int(x.NamePos) + len(x.Name)) }
-func (x *BasicLit) End() token.Pos     { return token.Pos(int(x.ValuePos) + len(x.Value)) }
+func (x *Ident) End() token.Pos        { return x.NamePos + token.Pos(len(x.Name)) }
+func (x *BasicLit) End() token.Pos     { return x.ValuePos + token.Pos(len(x.Value)) }
 func (x *CompositeLit) End() token.Pos { return x.RightBrace + 1 }
 func (x *FuncLit) End() token.Pos      { return x.Body.End() }
 func (x *BinaryExpr) End() token.Pos   { return x.Y.End() }
@@ -213,46 +317,50 @@ func (x *Ellipsis) End() token.Pos {
 	}
 	return x.Ellipsis + token.Pos(len("..."))
 }
-func (x *IndexExpr) End() token.Pos     { return x.RightBrack + 1 }
-func (x *IndexListExpr) End() token.Pos { return x.RightBrack + 1 }
-func (x *KeyValueExpr) End() token.Pos  { return x.Value.End() }
-func (x *ParenExpr) End() token.Pos     { return x.RightParen + 1 }
-func (x *SelectorExpr) End() token.Pos  { return x.Sel.End() }
-func (x *SliceExpr) End() token.Pos     { return x.RightBrack + 1 }
-func (x *StarExpr) End() token.Pos      { return x.X.End() }
-func (x *UnaryExpr) End() token.Pos     { return x.X.End() }
-func (x *ArrayType) End() token.Pos     { return x.ElemType.End() }
+func (x *IndexExpr) End() token.Pos      { return x.RightBrack + 1 }
+func (x *IndexListExpr) End() token.Pos  { return x.RightBrack + 1 }
+func (x *KeyValueExpr) End() token.Pos   { return x.Value.End() }
+func (x *ParenExpr) End() token.Pos      { return x.RightParen + 1 }
+func (x *SelectorExpr) End() token.Pos   { return x.Sel.End() }
+func (x *SliceExpr) End() token.Pos      { return x.RightBrack + 1 }
+func (x *StarExpr) End() token.Pos       { return x.X.End() }
+func (x *TypeAssertExpr) End() token.Pos { return x.RightParen + 1 }
+func (x *UnaryExpr) End() token.Pos      { return x.X.End() }
+func (x *ArrayType) End() token.Pos      { return x.ElemType.End() }
 func (x *FuncType) End() token.Pos {
 	if x.Results != nil {
 		return x.Results.End()
 	}
 	return x.Params.End()
 }
-func (x *MapType) End() token.Pos    { return x.ValueType.End() }
-func (x *SliceType) End() token.Pos  { return x.ElemType.End() }
-func (x *StructType) End() token.Pos { return x.Fields.End() }
-
-func (*BadExpr) exprNode()       {}
-func (*Ident) exprNode()         {}
-func (*BasicLit) exprNode()      {}
-func (*CompositeLit) exprNode()  {}
-func (*FuncLit) exprNode()       {}
-func (*BinaryExpr) exprNode()    {}
-func (*CallExpr) exprNode()      {}
-func (*Ellipsis) exprNode()      {}
-func (*IndexExpr) exprNode()     {}
-func (*IndexListExpr) exprNode() {}
-func (*KeyValueExpr) exprNode()  {}
-func (*ParenExpr) exprNode()     {}
-func (*SelectorExpr) exprNode()  {}
-func (*SliceExpr) exprNode()     {}
-func (*StarExpr) exprNode()      {}
-func (*UnaryExpr) exprNode()     {}
-func (*ArrayType) exprNode()     {}
-func (*FuncType) exprNode()      {}
-func (*MapType) exprNode()       {}
-func (*SliceType) exprNode()     {}
-func (*StructType) exprNode()    {}
+func (x *OptionalType) End() token.Pos { return x.Question + 1 }
+func (x *MapType) End() token.Pos      { return x.ValueType.End() }
+func (x *SliceType) End() token.Pos    { return x.ElemType.End() }
+func (x *StructType) End() token.Pos   { return x.Fields.End() }
+
+func (*BadExpr) exprNode()        {}
+func (*Ident) exprNode()          {}
+func (*BasicLit) exprNode()       {}
+func (*CompositeLit) exprNode()   {}
+func (*FuncLit) exprNode()        {}
+func (*BinaryExpr) exprNode()     {}
+func (*CallExpr) exprNode()       {}
+func (*Ellipsis) exprNode()       {}
+func (*IndexExpr) exprNode()      {}
+func (*IndexListExpr) exprNode()  {}
+func (*KeyValueExpr) exprNode()   {}
+func (*ParenExpr) exprNode()      {}
+func (*SelectorExpr) exprNode()   {}
+func (*SliceExpr) exprNode()      {}
+func (*StarExpr) exprNode()       {}
+func (*TypeAssertExpr) exprNode() {}
+func (*UnaryExpr) exprNode()      {}
+func (*ArrayType) exprNode()      {}
+func (*FuncType) exprNode()       {}
+func (*OptionalType) exprNode()   {}
+func (*MapType) exprNode()        {}
+func (*SliceType) exprNode()      {}
+func (*StructType) exprNode()     {}
 
 var _ = []Node{
 	&BadExpr{},
@@ -271,11 +379,13 @@ var _ = []Node{
 	&SelectorExpr{},
 	&SliceExpr{},
 	&StarExpr{},
+	&TypeAssertExpr{},
 	&UnaryExpr{},
 
 	&ArrayType{},
 	&FuncType{},
 	&MapType{},
+	&OptionalType{},
 	&SliceType{},
 	&StructType{},
 }
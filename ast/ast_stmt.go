@@ -101,20 +101,29 @@ type SwitchStmt struct {
 	Body   *BlockStmt // CaseStmts only
 }
 
-func (s *BadStmt) Pos() token.Pos     { return s.From }
-func (s *AssignStmt) Pos() token.Pos  { return s.LHS[0].Pos() }
-func (s *BlockStmt) Pos() token.Pos   { return s.LeftBrace }
-func (s *BranchStmt) Pos() token.Pos  { return s.TokPos }
-func (s *CaseStmt) Pos() token.Pos    { return s.Case }
-func (s *DeclStmt) Pos() token.Pos    { return s.Decl.Pos() }
-func (s *DeferStmt) Pos() token.Pos   { return s.Defer }
-func (s *EmptyStmt) Pos() token.Pos   { return s.Semicolon }
-func (s *ExprStmt) Pos() token.Pos    { return s.X.Pos() }
-func (s *ForStmt) Pos() token.Pos     { return s.For }
-func (s *IfStmt) Pos() token.Pos      { return s.If }
-func (s *LabeledStmt) Pos() token.Pos { return s.Label.Pos() }
-func (s *ReturnStmt) Pos() token.Pos  { return s.Return }
-func (s *SwitchStmt) Pos() token.Pos  { return s.Switch }
+// TypeSwitchStmt node represents a type switch statement.
+type TypeSwitchStmt struct {
+	Switch token.Pos  // position of "switch" keyword
+	Init   Stmt       // initialization statement; or nil
+	Assign Stmt       // x := y.(typedef) or y.(typedef) (ExprStmt)
+	Body   *BlockStmt // CaseStmts only
+}
+
+func (s *BadStmt) Pos() token.Pos        { return s.From }
+func (s *AssignStmt) Pos() token.Pos     { return s.LHS[0].Pos() }
+func (s *BlockStmt) Pos() token.Pos      { return s.LeftBrace }
+func (s *BranchStmt) Pos() token.Pos     { return s.TokPos }
+func (s *CaseStmt) Pos() token.Pos       { return s.Case }
+func (s *DeclStmt) Pos() token.Pos       { return s.Decl.Pos() }
+func (s *DeferStmt) Pos() token.Pos      { return s.Defer }
+func (s *EmptyStmt) Pos() token.Pos      { return s.Semicolon }
+func (s *ExprStmt) Pos() token.Pos       { return s.X.Pos() }
+func (s *ForStmt) Pos() token.Pos        { return s.For }
+func (s *IfStmt) Pos() token.Pos         { return s.If }
+func (s *LabeledStmt) Pos() token.Pos    { return s.Label.Pos() }
+func (s *ReturnStmt) Pos() token.Pos     { return s.Return }
+func (s *SwitchStmt) Pos() token.Pos     { return s.Switch }
+func (s *TypeSwitchStmt) Pos() token.Pos { return s.Switch }
 
 func (s *BadStmt) End() token.Pos    { return s.To }
 func (s *AssignStmt) End() token.Pos { return s.RHS[len(s.RHS)-1].End() }
@@ -132,7 +141,7 @@ func (s *BranchStmt) End() token.Pos {
 	if s.Label != nil {
 		return s.Label.End()
 	}
-	return token.Pos(int(s.TokPos) + len(s.Tok.String()))
+	return s.TokPos + token.Pos(len(s.Tok.String()))
 }
 
 func (s *CaseStmt) End() token.Pos {
@@ -164,22 +173,24 @@ func (s *ReturnStmt) End() token.Pos {
 	}
 	return s.Return + token.Pos(len("return"))
 }
-func (s *SwitchStmt) End() token.Pos { return s.Body.End() }
-
-func (*BadStmt) stmtNode()     {}
-func (*AssignStmt) stmtNode()  {}
-func (*BlockStmt) stmtNode()   {}
-func (*BranchStmt) stmtNode()  {}
-func (*CaseStmt) stmtNode()    {}
-func (*DeclStmt) stmtNode()    {}
-func (*DeferStmt) stmtNode()   {}
-func (*EmptyStmt) stmtNode()   {}
-func (*ExprStmt) stmtNode()    {}
-func (*ForStmt) stmtNode()     {}
-func (*IfStmt) stmtNode()      {}
-func (*LabeledStmt) stmtNode() {}
-func (*ReturnStmt) stmtNode()  {}
-func (*SwitchStmt) stmtNode()  {}
+func (s *SwitchStmt) End() token.Pos     { return s.Body.End() }
+func (s *TypeSwitchStmt) End() token.Pos { return s.Body.End() }
+
+func (*BadStmt) stmtNode()        {}
+func (*AssignStmt) stmtNode()     {}
+func (*BlockStmt) stmtNode()      {}
+func (*BranchStmt) stmtNode()     {}
+func (*CaseStmt) stmtNode()       {}
+func (*DeclStmt) stmtNode()       {}
+func (*DeferStmt) stmtNode()      {}
+func (*EmptyStmt) stmtNode()      {}
+func (*ExprStmt) stmtNode()       {}
+func (*ForStmt) stmtNode()        {}
+func (*IfStmt) stmtNode()         {}
+func (*LabeledStmt) stmtNode()    {}
+func (*ReturnStmt) stmtNode()     {}
+func (*SwitchStmt) stmtNode()     {}
+func (*TypeSwitchStmt) stmtNode() {}
 
 var _ = []Node{
 	&BadStmt{},
@@ -196,4 +207,5 @@ var _ = []Node{
 	&LabeledStmt{},
 	&ReturnStmt{},
 	&SwitchStmt{},
+	&TypeSwitchStmt{},
 }
@@ -1,7 +1,11 @@
 package ast
 
 import (
+	"reflect"
+	"slices"
 	"testing"
+
+	"github.com/stable-lang/stlang/token"
 )
 
 func TestCommentText(t *testing.T) {
@@ -45,3 +49,86 @@ func TestCommentText(t *testing.T) {
 		}
 	}
 }
+
+func TestBasicLitValue(t *testing.T) {
+	x := &BasicLit{Kind: token.Int, Value: "0x_ff"}
+	v, err := x.IntValue()
+	if err != nil || v.Int64() != 255 {
+		t.Fatalf("IntValue() = %v, %v; want 255", v, err)
+	}
+	if v2, _ := x.IntValue(); v2 != v {
+		t.Errorf("IntValue() is not cached")
+	}
+
+	x.Value = "42"
+	if v, _ := x.IntValue(); v.Int64() != 42 {
+		t.Errorf("IntValue() = %v after change; want 42", v)
+	}
+	if _, err := x.StringValue(); err == nil {
+		t.Errorf("StringValue() of an INT literal must fail")
+	}
+
+	s := &BasicLit{Kind: token.String, Value: `"a\tb"`}
+	if v, err := s.StringValue(); err != nil || v != "a\tb" {
+		t.Errorf("StringValue() = %q, %v; want %q", v, err, "a\tb")
+	}
+
+	c := &BasicLit{Kind: token.Char, Value: `'\n'`}
+	if v, err := c.CharValue(); err != nil || v != '\n' {
+		t.Errorf("CharValue() = %q, %v; want %q", v, err, '\n')
+	}
+	c.Kind = token.String
+	if _, err := c.StringValue(); err == nil {
+		t.Errorf("StringValue() of a char literal must fail")
+	}
+
+	f := &BasicLit{Kind: token.Float, Value: "1_000.5"}
+	if v, err := f.FloatValue(); err != nil || v.String() != "1000.5" {
+		t.Errorf("FloatValue() = %v, %v; want 1000.5", v, err)
+	}
+
+	bad := &BasicLit{Kind: token.Int, Value: "1__0"}
+	if _, err := bad.IntValue(); err == nil {
+		t.Errorf("IntValue() of %s must fail", bad.Value)
+	}
+}
+
+func TestTypeAssert(t *testing.T) {
+	// x.(T) and switch y.(typedef) {}
+	assert := &TypeAssertExpr{
+		X:          &Ident{NamePos: 10, Name: "x"},
+		LeftParen:  12,
+		Type:       &Ident{NamePos: 13, Name: "T"},
+		RightParen: 14,
+	}
+	guard := &TypeAssertExpr{X: &Ident{NamePos: 27, Name: "y"}, LeftParen: 29, RightParen: 37}
+	sw := &TypeSwitchStmt{
+		Switch: 20,
+		Assign: &ExprStmt{X: guard},
+		Body:   &BlockStmt{LeftBrace: 39, RightBrace: 40},
+	}
+
+	if assert.Pos() != 10 || assert.End() != 15 {
+		t.Errorf("assertion: have range [%d, %d), want [10, 15)", assert.Pos(), assert.End())
+	}
+	if sw.Pos() != 20 || sw.End() != 41 {
+		t.Errorf("type switch: have range [%d, %d), want [20, 41)", sw.Pos(), sw.End())
+	}
+
+	var have []string
+	for _, n := range []Node{assert, sw} {
+		Inspect(n, func(n Node) bool {
+			if n != nil {
+				have = append(have, reflect.TypeOf(n).Elem().Name())
+			}
+			return true
+		})
+	}
+	want := []string{
+		"TypeAssertExpr", "Ident", "Ident",
+		"TypeSwitchStmt", "ExprStmt", "TypeAssertExpr", "Ident", "BlockStmt",
+	}
+	if !slices.Equal(have, want) {
+		t.Errorf("have nodes %v, want %v", have, want)
+	}
+}
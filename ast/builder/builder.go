@@ -0,0 +1,339 @@
+// Package builder provides helpers to construct syntax trees for Stable source code.
+package builder
+
+import (
+	"strconv"
+
+	"github.com/stable-lang/stlang/ast"
+	"github.com/stable-lang/stlang/token"
+)
+
+// B is a [Builder] producing nodes without positions.
+var B Builder
+
+// Builder constructs AST nodes with all positions set to the same value.
+// The zero Builder uses [token.NoPos].
+type Builder struct {
+	pos token.Pos
+}
+
+// At returns a builder that sets all positions of the produced nodes to pos.
+func (b Builder) At(pos token.Pos) Builder {
+	return Builder{pos: pos}
+}
+
+// Pos returns the position used for the produced nodes.
+func (b Builder) Pos() token.Pos { return b.pos }
+
+// expressions
+
+// Ident returns an identifier with the given name.
+func (b Builder) Ident(name string) *ast.Ident {
+	return &ast.Ident{
+		NamePos: b.pos,
+		Name:    name,
+	}
+}
+
+// Idents returns a list of identifiers with the given names.
+func (b Builder) Idents(names ...string) []*ast.Ident {
+	idents := make([]*ast.Ident, len(names))
+	for i, name := range names {
+		idents[i] = b.Ident(name)
+	}
+	return idents
+}
+
+// Lit returns a basic literal of the given kind with value as its source text.
+func (b Builder) Lit(kind token.Token, value string) *ast.BasicLit {
+	return &ast.BasicLit{
+		ValuePos: b.pos,
+		Kind:     kind,
+		Value:    value,
+	}
+}
+
+// Int returns an integer literal.
+func (b Builder) Int(v int) *ast.BasicLit {
+	return b.Lit(token.Int, strconv.Itoa(v))
+}
+
+// String returns a quoted string literal.
+func (b Builder) String(s string) *ast.BasicLit {
+	return b.Lit(token.String, strconv.Quote(s))
+}
+
+// Binary returns a binary expression x op y.
+func (b Builder) Binary(x ast.Expr, op token.Token, y ast.Expr) *ast.BinaryExpr {
+	return &ast.BinaryExpr{
+		X:     x,
+		OpPos: b.pos,
+		Op:    op,
+		Y:     y,
+	}
+}
+
+// Unary returns a unary expression op x.
+// The "*" operator produces an [ast.StarExpr].
+func (b Builder) Unary(op token.Token, x ast.Expr) ast.Expr {
+	if op == token.Mul {
+		return b.Star(x)
+	}
+	return &ast.UnaryExpr{
+		OpPos: b.pos,
+		Op:    op,
+		X:     x,
+	}
+}
+
+// Star returns an expression *x.
+func (b Builder) Star(x ast.Expr) *ast.StarExpr {
+	return &ast.StarExpr{
+		Star: b.pos,
+		X:    x,
+	}
+}
+
+// Paren returns a parenthesized expression (x).
+func (b Builder) Paren(x ast.Expr) *ast.ParenExpr {
+	return &ast.ParenExpr{
+		LeftParen:  b.pos,
+		X:          x,
+		RightParen: b.pos,
+	}
+}
+
+// Call returns a call expression fun(args...).
+func (b Builder) Call(fun ast.Expr, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun:        fun,
+		LeftParen:  b.pos,
+		Args:       args,
+		RightParen: b.pos,
+	}
+}
+
+// Sel returns a selector expression x.sel.
+func (b Builder) Sel(x ast.Expr, sel string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   x,
+		Sel: b.Ident(sel),
+	}
+}
+
+// Index returns an index expression x[index].
+func (b Builder) Index(x, index ast.Expr) *ast.IndexExpr {
+	return &ast.IndexExpr{
+		X:          x,
+		LeftBrack:  b.pos,
+		Index:      index,
+		RightBrack: b.pos,
+	}
+}
+
+// TypeAssert returns a type assertion x.(typ).
+// A nil typ produces the type switch guard x.(typedef).
+func (b Builder) TypeAssert(x, typ ast.Expr) *ast.TypeAssertExpr {
+	return &ast.TypeAssertExpr{
+		X:          x,
+		LeftParen:  b.pos,
+		Type:       typ,
+		RightParen: b.pos,
+	}
+}
+
+// Composite returns a composite literal typ{elems...}.
+func (b Builder) Composite(typ ast.Expr, elems ...ast.Expr) *ast.CompositeLit {
+	return &ast.CompositeLit{
+		Type:       typ,
+		LeftBrace:  b.pos,
+		ElemTypes:  elems,
+		RightBrace: b.pos,
+	}
+}
+
+// KeyValue returns a key: value pair for composite literals.
+func (b Builder) KeyValue(key, value ast.Expr) *ast.KeyValueExpr {
+	return &ast.KeyValueExpr{
+		Key:   key,
+		Colon: b.pos,
+		Value: value,
+	}
+}
+
+// types
+
+// Field returns a field or parameter declaration.
+func (b Builder) Field(typ ast.Expr, names ...string) *ast.Field {
+	var idents []*ast.Ident
+	if len(names) > 0 {
+		idents = b.Idents(names...)
+	}
+	return &ast.Field{
+		Names: idents,
+		Type:  typ,
+	}
+}
+
+// Fields returns a field list enclosed by parentheses or braces.
+func (b Builder) Fields(fields ...*ast.Field) *ast.FieldList {
+	return &ast.FieldList{
+		Opening: b.pos,
+		List:    fields,
+		Closing: b.pos,
+	}
+}
+
+// Optional returns a nullable type typ?.
+func (b Builder) Optional(typ ast.Expr) *ast.OptionalType {
+	return &ast.OptionalType{
+		X:        typ,
+		Question: b.pos,
+	}
+}
+
+// FuncType returns a function signature.
+// If results is nil, the function has no results.
+func (b Builder) FuncType(params, results *ast.FieldList) *ast.FuncType {
+	if params == nil {
+		params = b.Fields()
+	}
+	return &ast.FuncType{
+		Func:    b.pos,
+		Params:  params,
+		Results: results,
+	}
+}
+
+// statements
+
+// Assign returns an assignment lhs tok rhs, where tok is an assignment token or [token.Define].
+func (b Builder) Assign(lhs []ast.Expr, tok token.Token, rhs ...ast.Expr) *ast.AssignStmt {
+	return &ast.AssignStmt{
+		LHS:    lhs,
+		TokPos: b.pos,
+		Tok:    tok,
+		RHS:    rhs,
+	}
+}
+
+// Define returns a short variable declaration name := value.
+func (b Builder) Define(name string, value ast.Expr) *ast.AssignStmt {
+	return b.Assign([]ast.Expr{b.Ident(name)}, token.Define, value)
+}
+
+// ExprStmt returns a stand-alone expression statement.
+func (b Builder) ExprStmt(x ast.Expr) *ast.ExprStmt {
+	return &ast.ExprStmt{X: x}
+}
+
+// Block returns a braced statement list.
+func (b Builder) Block(list ...ast.Stmt) *ast.BlockStmt {
+	return &ast.BlockStmt{
+		LeftBrace:  b.pos,
+		List:       list,
+		RightBrace: b.pos,
+	}
+}
+
+// If returns an if statement; els may be nil.
+func (b Builder) If(cond ast.Expr, body *ast.BlockStmt, els ast.Stmt) *ast.IfStmt {
+	return &ast.IfStmt{
+		If:   b.pos,
+		Cond: cond,
+		Body: body,
+		Else: els,
+	}
+}
+
+// Return returns a return statement.
+func (b Builder) Return(results ...ast.Expr) *ast.ReturnStmt {
+	return &ast.ReturnStmt{
+		Return:  b.pos,
+		Results: results,
+	}
+}
+
+// declarations
+
+// Import returns an import declaration; name may be empty.
+func (b Builder) Import(name, path string) *ast.ImportDecl {
+	var ident *ast.Ident
+	if name != "" {
+		ident = b.Ident(name)
+	}
+	return &ast.ImportDecl{
+		Name: ident,
+		Path: b.String(path),
+	}
+}
+
+// Const returns a constant declaration; typ may be nil.
+func (b Builder) Const(name string, typ, value ast.Expr) *ast.ConstDecl {
+	return &ast.ConstDecl{
+		Name:  b.Ident(name),
+		Type:  typ,
+		Value: value,
+	}
+}
+
+// Var returns a variable declaration; typ may be nil.
+func (b Builder) Var(name string, typ, value ast.Expr) *ast.VarDecl {
+	return &ast.VarDecl{
+		Name:  b.Ident(name),
+		Type:  typ,
+		Value: value,
+	}
+}
+
+// Struct returns a structure declaration.
+func (b Builder) Struct(name string, fields ...*ast.Field) *ast.StructDecl {
+	return &ast.StructDecl{
+		Name:   b.Ident(name),
+		Fields: b.Fields(fields...),
+	}
+}
+
+// Typedef returns a type definition.
+func (b Builder) Typedef(name string, typ ast.Expr) *ast.TypedefDecl {
+	return &ast.TypedefDecl{
+		Name: b.Ident(name),
+		Type: typ,
+	}
+}
+
+// Func returns a function declaration.
+// A nil body declares an external (non-Stable) function.
+func (b Builder) Func(name string, typ *ast.FuncType, body *ast.BlockStmt) *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: b.Ident(name),
+		Type: typ,
+		Body: body,
+	}
+}
+
+// Method returns a method declaration with the given receiver.
+func (b Builder) Method(recv, name string, typ *ast.FuncType, body *ast.BlockStmt) *ast.FuncDecl {
+	decl := b.Func(name, typ, body)
+	decl.Recv = b.Ident(recv)
+	return decl
+}
+
+// File returns a source file with the given package name and declarations.
+// Imports are collected from decls.
+func (b Builder) File(pkg string, decls ...ast.Decl) *ast.File {
+	var imports []*ast.ImportDecl
+	for _, decl := range decls {
+		if imp, ok := decl.(*ast.ImportDecl); ok {
+			imports = append(imports, imp)
+		}
+	}
+	return &ast.File{
+		FileStart: b.pos,
+		FileEnd:   b.pos,
+		Package:   b.pos,
+		PkgName:   b.Ident(pkg),
+		Imports:   imports,
+		Decls:     decls,
+	}
+}
@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stable-lang/stlang/ast"
+	"github.com/stable-lang/stlang/token"
+)
+
+func TestBuilder(t *testing.T) {
+	fn := B.Func("add",
+		B.FuncType(B.Fields(B.Field(B.Ident("int"), "a", "b")), B.Fields(B.Field(B.Ident("int")))),
+		B.Block(
+			B.Define("c", B.Binary(B.Ident("a"), token.Add, B.Ident("b"))),
+			B.Return(B.Ident("c")),
+		),
+	)
+	f := B.File("p", B.Import("", "fmt"), fn)
+
+	if len(f.Imports) != 1 || f.Imports[0].Path.Value != `"fmt"` {
+		t.Errorf("have imports %v, want one import of \"fmt\"", f.Imports)
+	}
+	if have := fn.Type.Params.List[0].Names; len(have) != 2 {
+		t.Errorf("have %d parameter names, want 2", len(have))
+	}
+	if fn.Pos() != token.NoPos {
+		t.Errorf("have position %d, want NoPos", fn.Pos())
+	}
+
+	pos := token.Pos(42)
+	sel := B.At(pos).Sel(B.At(pos).Ident("fmt"), "Println")
+	if sel.Pos() != pos || sel.Sel.Pos() != pos {
+		t.Errorf("have positions %d and %d, want %d", sel.Pos(), sel.Sel.Pos(), pos)
+	}
+
+	if _, ok := B.Unary(token.Mul, B.Ident("p")).(*ast.StarExpr); !ok {
+		t.Errorf("unary '*' must produce a StarExpr")
+	}
+
+	assert := B.At(pos).TypeAssert(B.At(pos).Ident("x"), B.Ident("T"))
+	if assert.Pos() != pos || assert.End() != pos+1 || assert.Type == nil {
+		t.Errorf("have assertion [%d, %d) of %v, want [%d, %d) of T", assert.Pos(), assert.End(), assert.Type, pos, pos+1)
+	}
+	if guard := B.TypeAssert(B.Ident("x"), nil); guard.Type != nil {
+		t.Errorf("have guard type %v, want nil", guard.Type)
+	}
+}
@@ -0,0 +1,90 @@
+package ast
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"reflect"
+
+	"github.com/stable-lang/stlang/token"
+)
+
+var (
+	posType           = reflect.TypeFor[token.Pos]()
+	commentGroupType  = reflect.TypeFor[*CommentGroup]()
+	commentGroupsType = reflect.TypeFor[[]*CommentGroup]()
+	emptyStmtType     = reflect.TypeFor[EmptyStmt]()
+)
+
+// ignoreField reports whether field sf of node type t only records formatting.
+func ignoreField(t reflect.Type, sf reflect.StructField) bool {
+	switch {
+	case !sf.IsExported():
+		return true
+	case sf.Type == posType, sf.Type == commentGroupType, sf.Type == commentGroupsType:
+		return true
+	case t == emptyStmtType && sf.Name == "Implicit":
+		return true // ";" and a newline are the same statement
+	}
+	return false
+}
+
+// Hash returns a structural fingerprint of the tree rooted at node, typically a declaration.
+// The hash depends on node kinds, names, operators and literal values only:
+// positions and comments are ignored, so formatting-only changes preserve it.
+func Hash(node Node) uint64 {
+	h := hasher{fnv.New64a()}
+	Inspect(node, h.visit)
+	return h.Sum64()
+}
+
+type hasher struct {
+	hash.Hash64
+}
+
+func (h hasher) visit(n Node) bool {
+	if n == nil {
+		h.Write([]byte{')'})
+		return false
+	}
+	if _, ok := n.(*CommentGroup); ok {
+		return false
+	}
+
+	v := reflect.ValueOf(n).Elem()
+	t := v.Type()
+	h.Write([]byte(t.Name()))
+	h.Write([]byte{'('})
+
+	// children are hashed by the traversal; record the values and
+	// the shape needed to tell apart optional children and lists.
+	var buf [binary.MaxVarintLen64]byte
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if ignoreField(t, sf) {
+			continue
+		}
+		switch f := v.Field(i); f.Kind() {
+		case reflect.String:
+			h.Write(binary.AppendUvarint(buf[:0], uint64(f.Len())))
+			h.Write([]byte(f.String()))
+		case reflect.Int, reflect.Int64:
+			h.Write(binary.AppendVarint(buf[:0], f.Int()))
+		case reflect.Bool:
+			if f.Bool() {
+				h.Write([]byte{1})
+			} else {
+				h.Write([]byte{0})
+			}
+		case reflect.Pointer, reflect.Interface:
+			if f.IsNil() {
+				h.Write([]byte{0})
+			} else {
+				h.Write([]byte{1})
+			}
+		case reflect.Slice:
+			h.Write(binary.AppendUvarint(buf[:0], uint64(f.Len())))
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stable-lang/stlang/token"
+)
+
+func TestHash(t *testing.T) {
+	f, g := testFile(), testFile()
+	if Hash(f) != Hash(g) {
+		t.Fatalf("equal trees must have equal hashes")
+	}
+
+	// positions and comments are ignored.
+	decl := g.Decls[0].(*FuncDecl)
+	decl.Name.NamePos = 42
+	decl.Doc = &CommentGroup{List: []*Comment{{Text: "// f adds one."}}}
+	if Hash(f) != Hash(g) {
+		t.Errorf("positions and comments must not change the hash")
+	}
+	g.Comments = []*CommentGroup{decl.Doc}
+	if Hash(f) != Hash(g) {
+		t.Errorf("file comments must not change the hash")
+	}
+
+	// an explicit ";" and a newline are the same empty statement.
+	if Hash(&EmptyStmt{Semicolon: 1}) != Hash(&EmptyStmt{Semicolon: 1, Implicit: true}) {
+		t.Errorf("implicit and explicit empty statements have different hashes")
+	}
+
+	ret := decl.Body.List[0].(*ReturnStmt)
+	bin := ret.Results[0].(*BinaryExpr)
+	changes := []struct {
+		name   string
+		change func()
+		undo   func()
+	}{
+		{"name", func() { decl.Name.Name = "g" }, func() { decl.Name.Name = "f" }},
+		{"operator", func() { bin.Op = token.Sub }, func() { bin.Op = token.Add }},
+		{"literal", func() { bin.Y.(*BasicLit).Value = "2" }, func() { bin.Y.(*BasicLit).Value = "1" }},
+		{"results", func() { ret.Results = nil }, func() { ret.Results = []Expr{bin} }},
+	}
+	for _, c := range changes {
+		c.change()
+		if Hash(f) == Hash(g) {
+			t.Errorf("%s: changed tree has the same hash", c.name)
+		}
+		c.undo()
+	}
+
+	// optional children are told apart.
+	x, a := &Ident{Name: "x"}, &Ident{Name: "a"}
+	if Hash(&SliceExpr{X: x, Low: a}) == Hash(&SliceExpr{X: x, High: a}) {
+		t.Errorf("x[a:] and x[:a] have the same hash")
+	}
+}
@@ -0,0 +1,120 @@
+package ast
+
+// NodeID identifies a node within the tree it was assigned in.
+// IDs start at 1; the zero NodeID denotes no node.
+type NodeID int
+
+// DeclKey identifies a node by its enclosing declaration rather than by its
+// position in the whole tree, so it survives edits to other declarations.
+// The zero DeclKey denotes no key.
+type DeclKey struct {
+	Decl  uint64 // Hash of the innermost enclosing declaration
+	Dup   int    // number of preceding declarations with the same hash
+	Index int    // pre-order index within the declaration, starting at 1 for the declaration itself
+}
+
+// isComment reports whether n is a comment, which [Hash] and declaration keys ignore.
+func isComment(n Node) bool {
+	switch n.(type) {
+	case *Comment, *CommentGroup:
+		return true
+	}
+	return false
+}
+
+// IDTable is a side table of node IDs assigned by [AssignIDs].
+type IDTable struct {
+	ids   map[Node]NodeID
+	nodes []Node    // indexed by NodeID-1
+	keys  []DeclKey // indexed by NodeID-1
+	byKey map[DeclKey]NodeID
+}
+
+// AssignIDs numbers the nodes of the tree rooted at root in pre-order, as visited by [Inspect].
+// The numbering depends only on the shape of the tree, not on positions,
+// so the same source yields the same IDs across tool invocations.
+//
+// As a fallback for trees that changed in between, nodes within a declaration
+// also get a [DeclKey] based on the declaration's structural [Hash]. The key of
+// a node stays valid as long as its declaration is unchanged, even if other
+// declarations are added, removed or edited.
+func AssignIDs(root Node) *IDTable {
+	t := &IDTable{
+		ids:   make(map[Node]NodeID),
+		byKey: make(map[DeclKey]NodeID),
+	}
+
+	type scope struct {
+		decl  Node
+		key   DeclKey
+		count int // nodes numbered within decl so far
+	}
+	var path []Node   // nodes being visited, from root
+	var decls []scope // enclosing declarations, innermost last
+	dups := make(map[uint64]int)
+
+	Inspect(root, func(n Node) bool {
+		if n == nil {
+			if len(decls) > 0 && decls[len(decls)-1].decl == path[len(path)-1] {
+				decls = decls[:len(decls)-1]
+			}
+			path = path[:len(path)-1]
+			return true
+		}
+		path = append(path, n)
+
+		t.nodes = append(t.nodes, n)
+		id := NodeID(len(t.nodes))
+		t.ids[n] = id
+
+		if d, ok := n.(Decl); ok {
+			h := Hash(d)
+			decls = append(decls, scope{decl: n, key: DeclKey{Decl: h, Dup: dups[h]}})
+			dups[h]++
+		}
+
+		var key DeclKey
+		if len(decls) > 0 && !isComment(n) {
+			s := &decls[len(decls)-1]
+			s.count++
+			key = s.key
+			key.Index = s.count
+			t.byKey[key] = id
+		}
+		t.keys = append(t.keys, key)
+		return true
+	})
+	return t
+}
+
+// Len returns the number of nodes in the table.
+func (t *IDTable) Len() int { return len(t.nodes) }
+
+// ID returns the ID of n, or 0 if n is not in the table.
+func (t *IDTable) ID(n Node) NodeID { return t.ids[n] }
+
+// Node returns the node with the given ID, or nil if there is none.
+func (t *IDTable) Node(id NodeID) Node {
+	if id < 1 || int(id) > len(t.nodes) {
+		return nil
+	}
+	return t.nodes[id-1]
+}
+
+// Key returns the declaration key of n, or the zero DeclKey
+// if n is not in the table, not within a declaration or a comment.
+func (t *IDTable) Key(n Node) DeclKey {
+	if id := t.ids[n]; id != 0 {
+		return t.keys[id-1]
+	}
+	return DeclKey{}
+}
+
+// Lookup returns the ID of the node with the given declaration key, or 0 if there is none.
+// The key may come from the table of an earlier version of the tree.
+func (t *IDTable) Lookup(key DeclKey) NodeID {
+	if key == (DeclKey{}) {
+		return 0
+	}
+	return t.byKey[key]
+}
@@ -0,0 +1,79 @@
+package ast
+
+import "testing"
+
+func TestAssignIDs(t *testing.T) {
+	f := testFile()
+	ids := AssignIDs(f)
+
+	if have, want := ids.Len(), Stats(f).Nodes; have != want {
+		t.Fatalf("have %d IDs, want %d", have, want)
+	}
+	if have := ids.ID(f); have != 1 {
+		t.Errorf("have root ID %d, want 1", have)
+	}
+	if have := ids.ID(f.PkgName); have != 2 {
+		t.Errorf("have package name ID %d, want 2", have)
+	}
+	for id := NodeID(1); int(id) <= ids.Len(); id++ {
+		if have := ids.ID(ids.Node(id)); have != id {
+			t.Errorf("ID(Node(%d)) = %d", id, have)
+		}
+	}
+	if ids.Node(0) != nil || ids.Node(NodeID(ids.Len()+1)) != nil || ids.ID(&Ident{}) != 0 {
+		t.Errorf("lookups of unknown IDs and nodes must fail")
+	}
+
+	// a second tree of the same shape gets the same IDs.
+	g := testFile()
+	other := AssignIDs(g)
+	body := g.Decls[0].(*FuncDecl).Body
+	if have, want := other.ID(body), ids.ID(f.Decls[0].(*FuncDecl).Body); have != want {
+		t.Errorf("have body ID %d, want %d", have, want)
+	}
+}
+
+func TestAssignIDsDeclKey(t *testing.T) {
+	f := testFile()
+	ids := AssignIDs(f)
+	if have := ids.Key(f); have != (DeclKey{}) {
+		t.Errorf("have key %v for the file, want none", have)
+	}
+
+	// a new declaration shifts the IDs but not the keys of other declarations.
+	g := testFile()
+	g.Decls = append([]Decl{&VarDecl{Name: &Ident{Name: "v"}, Value: &Ident{Name: "x"}}}, g.Decls...)
+	other := AssignIDs(g)
+
+	body, oldBody := g.Decls[1].(*FuncDecl).Body, f.Decls[0].(*FuncDecl).Body
+	if other.ID(body) == ids.ID(oldBody) {
+		t.Fatalf("have the same body ID %d, want a shifted one", other.ID(body))
+	}
+	if have := other.Node(other.Lookup(ids.Key(oldBody))); have != body {
+		t.Errorf("body key resolves to %v, want the body", have)
+	}
+	if have := other.Lookup(DeclKey{}); have != 0 {
+		t.Errorf("zero key resolves to %d, want 0", have)
+	}
+
+	// a doc comment neither changes the declaration hash nor shifts the index.
+	h := testFile()
+	fn := h.Decls[0].(*FuncDecl)
+	fn.Doc = &CommentGroup{List: []*Comment{{Text: "// f adds one."}}}
+	commented := AssignIDs(h)
+	if have := commented.Node(commented.Lookup(ids.Key(oldBody))); have != fn.Body {
+		t.Errorf("body key resolves to %v after adding a doc comment, want the body", have)
+	}
+	if have := commented.Key(fn.Doc); have != (DeclKey{}) {
+		t.Errorf("have key %v for a comment, want none", have)
+	}
+
+	// identical declarations are told apart by their order.
+	dup := testFile()
+	dup.Decls = append(dup.Decls, testFile().Decls[0])
+	dups := AssignIDs(dup)
+	k0, k1 := dups.Key(dup.Decls[0]), dups.Key(dup.Decls[1])
+	if k0.Decl != k1.Decl || k0 == k1 {
+		t.Errorf("have keys %v and %v, want the same hash and different keys", k0, k1)
+	}
+}
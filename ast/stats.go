@@ -0,0 +1,69 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// NodeStats holds statistics about a syntax tree, as computed by [Stats].
+type NodeStats struct {
+	Nodes int            // number of nodes
+	Kinds map[string]int // number of nodes by type name, e.g. "Ident"
+	Depth int            // maximum nesting depth; a single node has depth 1
+	Bytes int64          // estimated memory used by the nodes, including their slices and strings
+}
+
+// Stats reports node counts by kind, the depth and the estimated memory of the tree rooted at node.
+// The memory estimate covers node structs, the backing arrays of their slices
+// and the contents of their strings; allocator overhead and sharing are not taken into account.
+func Stats(node Node) NodeStats {
+	s := NodeStats{Kinds: make(map[string]int)}
+	depth := 0
+	Inspect(node, func(n Node) bool {
+		if n == nil {
+			depth--
+			return false
+		}
+		depth++
+		s.Depth = max(s.Depth, depth)
+
+		v := reflect.ValueOf(n).Elem()
+		s.Nodes++
+		s.Kinds[v.Type().Name()]++
+		s.Bytes += nodeSize(v)
+		return true
+	})
+	return s
+}
+
+// nodeSize returns the size of the node struct v and the data referenced by its slices and strings.
+func nodeSize(v reflect.Value) int64 {
+	size := int64(v.Type().Size())
+	for i := 0; i < v.NumField(); i++ {
+		switch f := v.Field(i); f.Kind() {
+		case reflect.String:
+			size += int64(f.Len())
+		case reflect.Slice:
+			size += int64(f.Cap()) * int64(f.Type().Elem().Size())
+		}
+	}
+	return size
+}
+
+// String returns a multi-line summary of the statistics, with kinds sorted by name.
+func (s NodeStats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "nodes: %d\ndepth: %d\nbytes: %d\n", s.Nodes, s.Depth, s.Bytes)
+
+	kinds := make([]string, 0, len(s.Kinds))
+	for kind := range s.Kinds {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+	for _, kind := range kinds {
+		fmt.Fprintf(&b, "%s: %d\n", kind, s.Kinds[kind])
+	}
+	return b.String()
+}
@@ -0,0 +1,23 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	s := Stats(testFile())
+
+	if s.Nodes != 17 {
+		t.Errorf("have %d nodes, want 17", s.Nodes)
+	}
+	if s.Kinds["Ident"] != 6 || s.Kinds["FieldList"] != 2 || s.Kinds["File"] != 1 {
+		t.Errorf("unexpected kinds %v", s.Kinds)
+	}
+	if s.Depth != 6 { // File FuncDecl BlockStmt ReturnStmt BinaryExpr Ident
+		t.Errorf("have depth %d, want 6", s.Depth)
+	}
+	if min := int64(s.Nodes) * int64(reflect.TypeOf(Ident{}).Size()); s.Bytes < min {
+		t.Errorf("have %d bytes, want at least %d", s.Bytes, min)
+	}
+}
@@ -0,0 +1,63 @@
+package ast
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stable-lang/stlang/token"
+)
+
+// testFile returns the tree of
+//
+//	package p
+//	func f(a int) int { return a + 1 }
+func testFile() *File {
+	a := &Ident{Name: "a"}
+	return &File{
+		PkgName: &Ident{Name: "p"},
+		Decls: []Decl{
+			&FuncDecl{
+				Name: &Ident{Name: "f"},
+				Type: &FuncType{
+					Params: &FieldList{List: []*Field{{
+						Names: []*Ident{a},
+						Type:  &Ident{Name: "int"},
+					}}},
+					Results: &FieldList{List: []*Field{{Type: &Ident{Name: "int"}}}},
+				},
+				Body: &BlockStmt{List: []Stmt{
+					&ReturnStmt{Results: []Expr{
+						&BinaryExpr{X: &Ident{Name: "a"}, Op: token.Add, Y: &BasicLit{Kind: token.Int, Value: "1"}},
+					}},
+				}},
+			},
+		},
+	}
+}
+
+func TestInspect(t *testing.T) {
+	var have []string
+	Inspect(testFile(), func(n Node) bool {
+		if n == nil {
+			have = append(have, ")")
+			return false
+		}
+		if _, ok := n.(*FuncType); ok {
+			have = append(have, "FuncType")
+			return false // skip the signature
+		}
+		have = append(have, reflect.TypeOf(n).Elem().Name())
+		return true
+	})
+
+	want := []string{
+		"File", "Ident", ")",
+		"FuncDecl", "Ident", ")", "FuncType",
+		"BlockStmt", "ReturnStmt", "BinaryExpr", "Ident", ")", "BasicLit", ")", ")", ")", ")",
+		")",
+		")",
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v\nwant %v", have, want)
+	}
+}
@@ -24,7 +24,7 @@ func main() {
 
 	fileSet := token.NewFileSet()
 	file := fileSet.AddFile("", fileSet.Base(), len(source))
-	s := lexer.NewLexer(file, []byte(source), nil)
+	s := lexer.NewLexer(file, []byte(source), nil, lexer.ScanComments)
 
 	for {
 		pos, tok, lit := s.Scan()
@@ -2,7 +2,10 @@
 package lexer
 
 import (
+	"bytes"
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"unicode/utf8"
 
 	"github.com/stable-lang/stlang/token"
@@ -13,6 +16,14 @@ const (
 	eof = -1     // end of file
 )
 
+// Mode is a set of flags (or 0) controlling the [Lexer] behavior.
+type Mode uint
+
+const (
+	ScanComments    Mode = 1 << iota // return comments as token.Comment; otherwise they are skipped
+	DontInsertSemis                  // do not automatically insert semicolons - for testing only
+)
+
 // ErrorHandler is an error handler for the [Lexer].
 // The position points to the beginning of the offending token.
 type ErrorHandler func(pos token.Position, msg string)
@@ -20,9 +31,13 @@ type ErrorHandler func(pos token.Position, msg string)
 // Lexer reads the Stable source text.
 type Lexer struct {
 	file     *token.File
+	dir      string // directory portion of file.Name()
 	src      []byte
 	errFn    ErrorHandler
+	mode     Mode
 	errCount int
+	firstErr token.Position // position of the first error
+	firstMsg string         // message of the first error
 
 	ch         rune      // current character
 	offset     int       // character offset
@@ -30,20 +45,38 @@ type Lexer struct {
 	lineOffset int       // current line offset
 	insertSemi bool      // insert a semicolon before next newline
 	nlPos      token.Pos // position of newline in preceding comment
+	depth      int       // nesting depth of parentheses, brackets and braces
+	period     bool      // previous non-comment token was '.'
 
-	noNewSemi bool // used only for testing
+	partial    bool // report incomplete constructs at EOF instead of errors
+	incomplete bool // source ended inside a construct that may continue
+
+	idents map[string]string // identifier literals scanned so far
 }
 
-// NewLexer creates a new [Lexer].
-func NewLexer(file *token.File, src []byte, err ErrorHandler) *Lexer {
+// NewLexer creates a new [Lexer] for src.
+// Comments are skipped unless mode contains [ScanComments];
+// a skipped comment allocates no literal.
+func NewLexer(file *token.File, src []byte, err ErrorHandler, mode Mode) *Lexer {
+	l := &Lexer{mode: mode}
+	l.Reset(file, src, err)
+	return l
+}
+
+// Reset prepares the lexer to scan src as the contents of file, keeping its mode.
+// All other state, including the error count, is cleared, so a single
+// Lexer can be reused for many files.
+func (l *Lexer) Reset(file *token.File, src []byte, err ErrorHandler) {
 	if file.Size() != len(src) {
 		panic(fmt.Sprintf("file size (%d) does not match src len (%d)", file.Size(), len(src)))
 	}
 
-	l := &Lexer{
+	*l = Lexer{
 		file:  file,
+		dir:   filepath.Dir(file.Name()),
 		src:   src,
 		errFn: err,
+		mode:  l.mode,
 		ch:    ' ',
 	}
 
@@ -51,7 +84,6 @@ func NewLexer(file *token.File, src []byte, err ErrorHandler) *Lexer {
 	if l.ch == bom {
 		l.next() // ignore BOM at file beginning
 	}
-	return l
 }
 
 // Scan the next token and returns the token position, the token and its literal string if applicable.
@@ -65,6 +97,7 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 		return pos, tok, lit
 	}
 
+scanAgain:
 	l.skipWhitespace()
 
 	pos = l.file.Pos(l.offset)
@@ -72,19 +105,30 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 
 	switch ch := l.ch; {
 	case isLetter(ch):
-		lit = l.scanIdent()
-		switch lit {
+		ident := l.scanIdent()
+		// Keywords and predeclared literals use static strings,
+		// identifiers allocate a literal once per name.
+		switch string(ident) {
 		case "nil":
-			tok = token.Nil
+			tok, lit = token.Nil, "nil"
 		case "true":
-			tok = token.True
+			tok, lit = token.True, "true"
 		case "false":
-			tok = token.False
+			tok, lit = token.False, "false"
 		default:
-			tok = token.Lookup(lit)
+			tok = token.Lookup(string(ident))
+			if tok != token.Ident && l.period {
+				// keywords are identifiers in selectors, e.g. x.any
+				tok = token.Ident
+			}
+			if tok == token.Ident {
+				lit = l.intern(ident)
+			} else {
+				lit = tok.String()
+			}
 			switch tok {
-			case token.Ident, token.Break,
-				token.Continue, token.Fallthrough,
+			case token.Ident, token.Any, token.Bool, token.Void,
+				token.Break, token.Continue, token.Fallthrough,
 				token.Return:
 				insertSemi = true
 			}
@@ -99,6 +143,9 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 
 		switch ch {
 		case eof:
+			if l.partial && l.depth > 0 {
+				l.incomplete = true
+			}
 			if l.insertSemi {
 				l.insertSemi = false // EOF consumed
 				return pos, token.Semicolon, "\n"
@@ -113,7 +160,13 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 		case '"':
 			insertSemi = true
 			tok = token.String
-			lit = l.scanString()
+			if l.ch == '"' && l.peek() == '"' {
+				l.next()
+				l.next() // consume opening '"""'
+				lit = l.scanMultilineString()
+			} else {
+				lit = l.scanString()
+			}
 		case '\'':
 			insertSemi = true
 			tok = token.Char
@@ -137,21 +190,30 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 		case ';':
 			tok = token.Semicolon
 			lit = ";"
+		case '?':
+			insertSemi = true
+			tok = token.Question
 
 		case '(':
+			l.depth++
 			tok = token.LeftParen
 		case '[':
+			l.depth++
 			tok = token.LeftBrack
 		case '{':
+			l.depth++
 			tok = token.LeftBrace
 
 		case ')':
+			l.closeDepth()
 			insertSemi = true
 			tok = token.RightParen
 		case ']':
+			l.closeDepth()
 			insertSemi = true
 			tok = token.RightBrack
 		case '}':
+			l.closeDepth()
 			insertSemi = true
 			tok = token.RightBrace
 
@@ -160,11 +222,23 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 		case '-':
 			tok = l.switch2(token.Sub, token.SubAssign)
 		case '*':
-			tok = l.switch2(token.Mul, token.MulAssign)
+			// "**" in operand or type position, e.g. **p, is
+			// two '*' and must be split by the parser.
+			tok = l.switch4(token.Mul, token.MulAssign, '*', token.Pow, token.PowAssign)
 		case '/':
 			if l.ch == '/' || l.ch == '*' {
 				// comment
 				comment, nlOffset := l.scanComment()
+				if l.mode&ScanComments == 0 {
+					// skip comment
+					if l.insertSemi && nlOffset != 0 {
+						// /*...*/ containing \n ends the line
+						l.insertSemi = false
+						l.period = false
+						return l.file.Pos(nlOffset), token.Semicolon, "\n"
+					}
+					goto scanAgain
+				}
 				if l.insertSemi && nlOffset != 0 {
 					// For /*...*/ containing \n, return
 					// COMMENT then artificial SEMICOLON.
@@ -174,7 +248,7 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 					insertSemi = l.insertSemi // preserve insertSemi info
 				}
 				tok = token.Comment
-				lit = comment
+				lit = string(comment)
 			} else {
 				// division
 				tok = l.switch2(token.Quo, token.QuoAssign)
@@ -184,9 +258,9 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 		case '^':
 			tok = l.switch2(token.Xor, token.XorAssign)
 		case '<':
-			tok = l.switch4(token.Less, token.LessEqual, '<', token.Shl, token.ShlAssign)
+			tok = l.switch6(token.Less, token.LessEqual, '<', token.Shl, token.ShlAssign, token.Rotl, token.RotlAssign)
 		case '>':
-			tok = l.switch4(token.Greater, token.GreaterEqual, '>', token.Shr, token.ShrAssign)
+			tok = l.switch6(token.Greater, token.GreaterEqual, '>', token.Shr, token.ShrAssign, token.Rotr, token.RotrAssign)
 		case '=':
 			tok = l.switch2(token.Assign, token.Equal)
 		case '!':
@@ -218,9 +292,12 @@ func (l *Lexer) Scan() (pos token.Pos, tok token.Token, lit string) {
 		}
 	}
 
-	if !l.noNewSemi {
+	if l.mode&DontInsertSemis == 0 {
 		l.insertSemi = insertSemi
 	}
+	if tok != token.Comment {
+		l.period = tok == token.Period
+	}
 	return pos, tok, lit
 }
 
@@ -278,14 +355,28 @@ func (l *Lexer) peek() byte {
 	return 0
 }
 
-// scanIdent reads the string of valid identifier characters at l.offset.
+// scanIdent reads the valid identifier characters at l.offset.
 // It must only be called when l.ch is known to be a valid letter.
-func (l *Lexer) scanIdent() string {
+func (l *Lexer) scanIdent() []byte {
 	offs := l.offset
 	for isLetter(l.ch) || isDecimal(l.ch) {
 		l.next()
 	}
-	return string(l.src[offs:l.offset])
+	return l.src[offs:l.offset]
+}
+
+// intern returns ident as a string that is shared by all
+// occurrences of the same identifier in the source.
+func (l *Lexer) intern(ident []byte) string {
+	if lit, ok := l.idents[string(ident)]; ok {
+		return lit
+	}
+	if l.idents == nil {
+		l.idents = make(map[string]string)
+	}
+	lit := string(ident)
+	l.idents[lit] = lit
+	return lit
 }
 
 func (l *Lexer) scanNumber() (token.Token, string) {
@@ -427,10 +518,10 @@ func invalidSep(x string) int {
 	return -1
 }
 
-// scanComment returns the text of the comment and (if nonzero)
-// the offset of the first newline within it, which implies a
-// /*...*/ comment.
-func (l *Lexer) scanComment() (string, int) {
+// scanComment returns the text of the comment, which refers to l.src
+// unless carriage returns were removed, and (if nonzero) the offset of
+// the first newline within it, which implies a /*...*/ comment.
+func (l *Lexer) scanComment() ([]byte, int) {
 	// initial '/' already consumed; l.ch == '/' || l.ch == '*'
 	offs := l.offset - 1 // position of initial '/'
 	next := -1           // position immediately following the comment; < 0 means invalid comment
@@ -472,7 +563,7 @@ func (l *Lexer) scanComment() (string, int) {
 		}
 	}
 
-	l.error(offs, "comment not terminated")
+	l.errorIncomplete(offs, "comment not terminated")
 
 exit:
 	lit := l.src[offs:l.offset]
@@ -483,10 +574,93 @@ exit:
 		numCR--
 	}
 
+	// interpret line directives
+	// (//line directives must start at the beginning of the current line)
+	if next >= 0 /* implies valid comment */ && (lit[1] == '*' || offs == l.lineOffset) && bytes.HasPrefix(lit[2:], linePrefix) {
+		l.updateLineInfo(next, offs, lit)
+	}
+
 	if numCR > 0 {
 		lit = stripCR(lit, lit[1] == '*')
 	}
-	return string(lit), nlOffset
+	return lit, nlOffset
+}
+
+var linePrefix = []byte("line ")
+
+// updateLineInfo parses the comment text at offset offs as a line directive.
+// If successful, it updates the line info table for the position next
+// per the line directive.
+func (l *Lexer) updateLineInfo(next, offs int, text []byte) {
+	// extract comment text
+	if text[1] == '*' {
+		text = text[:len(text)-2] // lop off trailing "*/"
+	}
+	text = text[7:] // lop off leading "//line " or "/*line "
+	offs += 7
+
+	i, n, ok := trailingDigits(text)
+	if i == 0 {
+		return // ignore (not a line directive)
+	}
+	// i > 0
+
+	if !ok {
+		// text has a suffix :xxx but xxx is not a number
+		l.error(offs+i, "invalid line number: "+string(text[i:]))
+		return
+	}
+
+	// Put a cap on the maximum size of line and column numbers.
+	// 30 bits allows for some additional space before wrapping an int32.
+	const maxLineCol = 1<<30 - 1
+	var line, col int
+	i2, n2, ok2 := trailingDigits(text[:i-1])
+	if ok2 {
+		//line filename:line:col
+		i, i2 = i2, i
+		line, col = n2, n
+		if col == 0 || col > maxLineCol {
+			l.error(offs+i2, "invalid column number: "+string(text[i2:]))
+			return
+		}
+		text = text[:i2-1] // lop off ":col"
+	} else {
+		//line filename:line
+		line = n
+	}
+
+	if line == 0 || line > maxLineCol {
+		l.error(offs+i, "invalid line number: "+string(text[i:]))
+		return
+	}
+
+	// If we have a column (//line filename:line:col form),
+	// an empty filename means to use the previous filename.
+	filename := string(text[:i-1]) // lop off ":line"
+	if filename == "" && ok2 {
+		filename = l.file.Position(l.file.Pos(offs)).Filename
+	} else if filename != "" {
+		// Put a relative filename in the directory of the current file.
+		filename = filepath.Clean(filename)
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(l.dir, filename)
+		}
+	}
+
+	l.file.AddLineColumnInfo(next, filename, line, col)
+}
+
+// trailingDigits returns the offset following the last ':' in text,
+// and the number after it, if any.
+func trailingDigits(text []byte) (int, int, bool) {
+	i := bytes.LastIndexByte(text, ':') // look from right (Windows filenames may contain ':')
+	if i < 0 {
+		return 0, 0, false // no ":"
+	}
+	// i >= 0
+	n, err := strconv.ParseUint(string(text[i+1:]), 10, 0)
+	return i + 1, int(n), err == nil
 }
 
 func (l *Lexer) scanString() string {
@@ -510,6 +684,46 @@ func (l *Lexer) scanString() string {
 	return string(l.src[offs:l.offset])
 }
 
+// scanMultilineString scans a """-delimited string, which may span multiple lines.
+// The literal is returned as written, except for carriage returns;
+// indentation is stripped when the value is computed by literal.Unquote.
+func (l *Lexer) scanMultilineString() string {
+	// '"""' opening already consumed
+	offs := l.offset - 3
+
+	hasCR := false
+	for {
+		ch := l.ch
+		if ch < 0 {
+			l.errorIncomplete(offs, "string literal not terminated")
+			break
+		}
+		l.next()
+		if ch == '"' && l.ch == '"' && l.peek() == '"' {
+			l.next()
+			l.next()
+			// the literal ends with the last '"""' of a run of quotes,
+			// so the body may end in quotes, e.g. """say "hi"""".
+			for l.ch == '"' {
+				l.next()
+			}
+			break
+		}
+		switch ch {
+		case '\\':
+			l.scanEscape('"')
+		case '\r':
+			hasCR = true
+		}
+	}
+
+	lit := l.src[offs:l.offset]
+	if hasCR {
+		lit = stripCR(lit, false)
+	}
+	return string(lit)
+}
+
 func (l *Lexer) scanRune() string {
 	// '\'' opening already consumed
 	offs := l.offset - 1
@@ -553,7 +767,7 @@ func (l *Lexer) scanRawString() string {
 	for {
 		ch := l.ch
 		if ch < 0 {
-			l.error(offs, "raw string literal not terminated")
+			l.errorIncomplete(offs, "raw string literal not terminated")
 			break
 		}
 		l.next()
@@ -636,6 +850,12 @@ func (l *Lexer) skipWhitespace() {
 	}
 }
 
+func (l *Lexer) closeDepth() {
+	if l.depth > 0 {
+		l.depth--
+	}
+}
+
 // Helper functions for scanning multi-byte tokens such as >> += >>= .
 
 func (l *Lexer) switch2(tok0, tok1 token.Token) token.Token {
@@ -674,15 +894,59 @@ func (l *Lexer) switch4(tok0, tok1 token.Token, ch2 rune, tok2, tok3 token.Token
 	return tok0
 }
 
+// switch6 is like switch4, but a third ch2 selects tok4 or tok5,
+// e.g. for < <= << <<= <<< <<<=.
+func (l *Lexer) switch6(tok0, tok1 token.Token, ch2 rune, tok2, tok3, tok4, tok5 token.Token) token.Token {
+	if l.ch != ch2 {
+		return l.switch2(tok0, tok1)
+	}
+	l.next()
+	if l.ch == ch2 {
+		l.next()
+		return l.switch2(tok4, tok5)
+	}
+	return l.switch2(tok2, tok3)
+}
+
+// ErrorCount returns the number of errors encountered so far.
+func (l *Lexer) ErrorCount() int {
+	return l.errCount
+}
+
+// FirstError returns the position and message of the first error encountered,
+// ok is false if there were no errors.
+func (l *Lexer) FirstError() (pos token.Position, msg string, ok bool) {
+	if l.errCount == 0 {
+		return token.Position{}, "", false
+	}
+	return l.firstErr, l.firstMsg, true
+}
+
+// errorIncomplete reports an error for a construct not terminated at EOF,
+// unless the lexer scans partial input.
+func (l *Lexer) errorIncomplete(offs int, msg string) {
+	if l.partial {
+		l.incomplete = true
+		return
+	}
+	l.error(offs, msg)
+}
+
 func (l *Lexer) errorf(offs int, format string, args ...any) {
 	l.error(offs, fmt.Sprintf(format, args...))
 }
 
 func (l *Lexer) error(offs int, msg string) {
-	l.errCount++
-	if l.errFn != nil {
-		l.errFn(l.file.Position(l.file.Pos(offs)), msg)
+	if l.errCount == 0 || l.errFn != nil {
+		pos := l.file.Position(l.file.Pos(offs))
+		if l.errCount == 0 {
+			l.firstErr, l.firstMsg = pos, msg
+		}
+		if l.errFn != nil {
+			l.errFn(pos, msg)
+		}
 	}
+	l.errCount++
 }
 
 func stripCR(b []byte, comment bool) []byte {
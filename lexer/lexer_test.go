@@ -67,6 +67,11 @@ var tokens = []elt{
 	},
 	{token.String, "`\r`", literal},
 	{token.String, "`foo\r\nbar`", literal},
+	{token.String, `""""""`, literal},
+	{token.String, `"""foo "bar" \""""`, literal},
+	{token.String, `"""say "hi""""`, literal},
+	{token.String, `""""quoted"""""`, literal},
+	{token.String, "\"\"\"\n\tfoo\n\t\"\"\"", literal},
 
 	{token.True, "true", literal},
 	{token.Nil, "nil", literal},
@@ -78,12 +83,15 @@ var tokens = []elt{
 	{token.Mul, "*", operator},
 	{token.Quo, "/", operator},
 	{token.Rem, "%", operator},
+	{token.Pow, "**", operator},
 	{token.And, "&", operator},
 	{token.Or, "|", operator},
 	{token.Xor, "^", operator},
 	{token.AndNot, "&^", operator},
 	{token.Shl, "<<", operator},
 	{token.Shr, ">>", operator},
+	{token.Rotl, "<<<", operator},
+	{token.Rotr, ">>>", operator},
 	{token.Concat, "++", operator},
 
 	{token.Assign, "=", operator},
@@ -92,12 +100,15 @@ var tokens = []elt{
 	{token.MulAssign, "*=", operator},
 	{token.QuoAssign, "/=", operator},
 	{token.RemAssign, "%=", operator},
+	{token.PowAssign, "**=", operator},
 	{token.AndAssign, "&=", operator},
 	{token.OrAssign, "|=", operator},
 	{token.XorAssign, "^=", operator},
 	{token.AndNotAssign, "&^=", operator},
 	{token.ShlAssign, "<<=", operator},
 	{token.ShrAssign, ">>=", operator},
+	{token.RotlAssign, "<<<=", operator},
+	{token.RotrAssign, ">>>=", operator},
 	{token.ConcatAssign, "++=", operator},
 
 	{token.LogicAnd, "&&", operator},
@@ -116,6 +127,7 @@ var tokens = []elt{
 	{token.Period, ".", operator},
 	{token.Colon, ":", operator},
 	{token.Semicolon, ";", operator},
+	{token.Question, "?", operator},
 
 	{token.LeftParen, "(", operator},
 	{token.LeftBrack, "[", operator},
@@ -154,8 +166,7 @@ func TestScan(t *testing.T) {
 	file := fset.AddFile("", fset.Base(), len(testSource))
 	s := NewLexer(file, testSource, func(_ token.Position, msg string) {
 		t.Errorf("error handler called (msg = %s)", msg)
-	})
-	s.noNewSemi = true
+	}, ScanComments|DontInsertSemis)
 
 	// set up expected position
 	epos := token.Position{
@@ -298,3 +309,151 @@ var testSource = func() []byte {
 	}
 	return src
 }()
+
+func TestErrorCount(t *testing.T) {
+	const src = "a := \"foo\n'ab' 0x\n"
+
+	var errs ErrorList
+	file := fset.AddFile("errors.st", fset.Base(), len(src))
+	l := NewLexer(file, []byte(src), errs.Add, 0)
+	for {
+		if _, tok, _ := l.Scan(); tok == token.EOF {
+			break
+		}
+	}
+
+	if l.ErrorCount() != 3 || errs.Len() != 3 {
+		t.Fatalf("have %d errors (%d collected), want 3: %v", l.ErrorCount(), errs.Len(), errs)
+	}
+
+	pos, msg, ok := l.FirstError()
+	if !ok || pos != errs[0].Pos || msg != errs[0].Msg {
+		t.Errorf("have first error %s: %s (%t), want %s", pos, msg, ok, errs[0])
+	}
+	if want := "errors.st:1:6: string literal not terminated"; errs[0].Error() != want {
+		t.Errorf("have %q, want %q", errs[0].Error(), want)
+	}
+}
+
+func TestSelectorKeyword(t *testing.T) {
+	const src = "x.any\ny . /* c */ case"
+
+	file := fset.AddFile("", fset.Base(), len(src))
+	l := NewLexer(file, []byte(src), nil, ScanComments)
+
+	want := []token.Token{
+		token.Ident, token.Period, token.Ident, token.Semicolon,
+		token.Ident, token.Period, token.Comment, token.Ident, token.Semicolon,
+		token.EOF,
+	}
+	for i, tok := range want {
+		_, have, lit := l.Scan()
+		if have != tok {
+			t.Errorf("token %d (%q): have %s, want %s", i, lit, have, tok)
+		}
+	}
+}
+
+func TestPowInOperand(t *testing.T) {
+	// "**" is a single token even where it means two '*';
+	// see token.Pow.
+	const src = "x = **p\nvar q **T"
+
+	file := fset.AddFile("", fset.Base(), len(src))
+	l := NewLexer(file, []byte(src), nil, 0)
+
+	want := []token.Token{
+		token.Ident, token.Assign, token.Pow, token.Ident, token.Semicolon,
+		token.Var, token.Ident, token.Pow, token.Ident, token.Semicolon,
+		token.EOF,
+	}
+	for i, tok := range want {
+		_, have, lit := l.Scan()
+		if have != tok {
+			t.Errorf("token %d (%q): have %s, want %s", i, lit, have, tok)
+		}
+	}
+}
+
+func TestSkipComments(t *testing.T) {
+	const src = "a // line\nb /* block\n */ c /* inline */ d\n/* last */"
+
+	wantFile := fset.AddFile("", fset.Base(), len(src))
+	var want []TokenInfo
+	for _, tok := range Tokenize(wantFile, []byte(src), nil) {
+		if tok.Tok != token.Comment {
+			want = append(want, tok)
+		}
+	}
+
+	file := fset.AddFile("", fset.Base(), len(src))
+	l := NewLexer(file, []byte(src), nil, 0)
+	for i := 0; ; i++ {
+		pos, tok, lit := l.Scan()
+		if tok == token.EOF {
+			if i != len(want) {
+				t.Errorf("have %d tokens, want %d", i, len(want))
+			}
+			break
+		}
+		if i >= len(want) {
+			t.Fatalf("unexpected token %s %q", tok, lit)
+		}
+		w := want[i]
+		if tok != w.Tok || lit != w.Lit || file.Offset(pos) != wantFile.Offset(w.Pos) {
+			t.Errorf("token %d: have %s %q at %d, want %s %q at %d", i, tok, lit, file.Offset(pos), w.Tok, w.Lit, wantFile.Offset(w.Pos))
+		}
+	}
+}
+
+func TestLineDirectives(t *testing.T) {
+	// the first directive ends in "\r\n" as on Windows.
+	const src = "a\n//line gen.st:10:5\r\nb\n/*line :20:1*/ c\n  //line not.st:1\nd\n/*line /abs/x.st:7:3*/e\n"
+
+	var errs ErrorList
+	file := fset.AddFile("dir/src.st", fset.Base(), len(src))
+	l := NewLexer(file, []byte(src), errs.Add, 0)
+
+	want := map[string]string{
+		"a": "dir/src.st:1:1",
+		"b": "dir/gen.st:10:5",
+		"c": "dir/gen.st:20:2",
+		"d": "dir/gen.st:22:1",
+		"e": "/abs/x.st:7:3",
+	}
+	for {
+		pos, tok, lit := l.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.Ident {
+			continue
+		}
+		if have := fset.Position(pos).String(); have != want[lit] {
+			t.Errorf("%s: have %s, want %s", lit, have, want[lit])
+		}
+	}
+	if errs.Len() != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestReset(t *testing.T) {
+	sources := []string{"a := \"foo\n", "package p\n// c\nvar x = 1\n", "\ufeffb"}
+
+	var errs ErrorList
+	l := NewLexer(fset.AddFile("", fset.Base(), 0), nil, nil, ScanComments)
+	for _, src := range sources {
+		errs.Reset()
+		file := fset.AddFile("", fset.Base(), len(src))
+		l.Reset(file, []byte(src), errs.Add)
+		have := l.ScanAll()
+
+		var fresh ErrorList
+		want := Tokenize(fset.AddFile("", fset.Base(), len(src)), []byte(src), fresh.Add)
+		checkSameTokens(t, have, want)
+		if l.ErrorCount() != fresh.Len() || errs.Len() != fresh.Len() {
+			t.Errorf("%q: have %d errors (%d collected), want %d", src, l.ErrorCount(), errs.Len(), fresh.Len())
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package lexer
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+
+	"github.com/stable-lang/stlang/token"
+)
+
+// TokenStats aggregates token frequencies and literal sizes over a corpus of token streams.
+// The zero value is ready to use.
+type TokenStats struct {
+	Streams  int                 // number of added streams
+	Tokens   int                 // number of tokens, excluding implicit semicolons
+	Implicit int                 // number of implicit semicolons
+	Counts   map[token.Token]int // number of tokens by kind
+
+	// LitSizes holds the distribution of literal lengths by kind for
+	// identifiers, basic literals and comments. Bucket i counts the literals
+	// whose length in bytes has a bit length of i, i.e. bucket 0 holds empty
+	// literals and bucket i > 0 the lengths in [1<<(i-1), 1<<i).
+	LitSizes map[token.Token][]int
+}
+
+// Add adds the tokens of ts to the statistics.
+func (s *TokenStats) Add(ts TokenStream) {
+	if s.Counts == nil {
+		s.Counts = make(map[token.Token]int)
+		s.LitSizes = make(map[token.Token][]int)
+	}
+
+	s.Streams++
+	for _, t := range ts {
+		if t.IsImplicit() {
+			s.Implicit++
+			continue
+		}
+		s.Tokens++
+		s.Counts[t.Tok]++
+
+		if t.Tok == token.Ident || t.Tok == token.Comment || t.Tok.IsLiteral() {
+			b := bits.Len(uint(len(t.Lit)))
+			sizes := s.LitSizes[t.Tok]
+			for len(sizes) <= b {
+				sizes = append(sizes, 0)
+			}
+			sizes[b]++
+			s.LitSizes[t.Tok] = sizes
+		}
+	}
+}
+
+// String returns a table of the token counts, most frequent first,
+// followed by the literal size distributions.
+func (s *TokenStats) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "streams: %d, tokens: %d, implicit semicolons: %d\n", s.Streams, s.Tokens, s.Implicit)
+
+	kinds := make([]token.Token, 0, len(s.Counts))
+	for tok := range s.Counts {
+		kinds = append(kinds, tok)
+	}
+	slices.SortFunc(kinds, func(a, b token.Token) int {
+		if c := cmp.Compare(s.Counts[b], s.Counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	for _, tok := range kinds {
+		fmt.Fprintf(&b, "%-12s %8d %6.2f%%\n", tok, s.Counts[tok], 100*float64(s.Counts[tok])/float64(s.Tokens))
+	}
+
+	for _, tok := range kinds {
+		sizes := s.LitSizes[tok]
+		if sizes == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s sizes:", tok)
+		for i, n := range sizes {
+			if n > 0 {
+				fmt.Fprintf(&b, " <%d:%d", 1<<i, n)
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
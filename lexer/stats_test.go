@@ -0,0 +1,42 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stable-lang/stlang/token"
+)
+
+func TestTokenStats(t *testing.T) {
+	var s TokenStats
+	for _, src := range []string{
+		"package p\nconst answer = 42\n",
+		"package q\n// a comment\nvar s = \"hello, world\"\n",
+	} {
+		fset := token.NewFileSet()
+		file := fset.AddFile("", fset.Base(), len(src))
+		s.Add(Tokenize(file, []byte(src), nil))
+	}
+
+	if s.Streams != 2 || s.Tokens != 13 || s.Implicit != 4 {
+		t.Errorf("have %d streams, %d tokens and %d implicit semicolons, want 2, 13 and 4", s.Streams, s.Tokens, s.Implicit)
+	}
+	if s.Counts[token.Package] != 2 || s.Counts[token.Ident] != 4 || s.Counts[token.Assign] != 2 {
+		t.Errorf("unexpected counts %v", s.Counts)
+	}
+
+	// "p", "q": bucket 1; "answer": bucket 3 ([4, 8)); "s": bucket 1.
+	if have := s.LitSizes[token.Ident]; len(have) != 4 || have[1] != 3 || have[3] != 1 {
+		t.Errorf("have ident sizes %v", have)
+	}
+	if have := s.LitSizes[token.String]; len(have) != 5 || have[4] != 1 {
+		t.Errorf("have string sizes %v", have)
+	}
+	if _, ok := s.LitSizes[token.Assign]; ok {
+		t.Errorf("operators must not have literal sizes")
+	}
+
+	if str := s.String(); !strings.Contains(str, "IDENT") || !strings.Contains(str, "tokens: 13") {
+		t.Errorf("unexpected summary:\n%s", str)
+	}
+}
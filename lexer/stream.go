@@ -0,0 +1,144 @@
+package lexer
+
+import (
+	"bytes"
+	"iter"
+	"slices"
+
+	"github.com/stable-lang/stlang/token"
+)
+
+// TokenInfo describes a single scanned token.
+type TokenInfo struct {
+	Pos token.Pos   // position of the first character of the token
+	End token.Pos   // position immediately after the token; equal to Pos for artificial tokens
+	Tok token.Token // token
+	Lit string      // literal string, as returned by [Lexer.Scan]
+}
+
+// IsImplicit reports whether the token is a semicolon inserted by the lexer.
+func (t TokenInfo) IsImplicit() bool {
+	return t.Tok == token.Semicolon && t.Lit == "\n"
+}
+
+// Text returns the source text of the token.
+// Implicit semicolons are represented by a newline.
+func (t TokenInfo) Text() string {
+	switch {
+	case t.Lit != "":
+		return t.Lit
+	case t.Tok.IsOperator() || t.Tok.IsKeyword():
+		return t.Tok.String()
+	default:
+		return ""
+	}
+}
+
+// TokenStream is a sequence of tokens in source order.
+// The terminating [token.EOF] is not a part of the stream.
+type TokenStream []TokenInfo
+
+// Tokenize scans the whole src and returns its tokens, including comments.
+// Errors are reported to err, if not nil.
+func Tokenize(file *token.File, src []byte, err ErrorHandler) TokenStream {
+	return NewLexer(file, src, err, ScanComments).ScanAll()
+}
+
+// ScanLine scans src as partial input, such as the lines entered so far in a REPL.
+//
+// Unlike [Tokenize], an unterminated raw string, """-delimited string or /*-style
+// comment and unclosed parentheses, brackets or braces at the end of src are not
+// reported as errors.
+// Instead incomplete is true, so the caller can ask for more input and scan
+// the extended source again. Other errors are reported to err, if not nil.
+func ScanLine(file *token.File, src []byte, err ErrorHandler) (ts TokenStream, incomplete bool) {
+	l := NewLexer(file, src, err, ScanComments)
+	l.partial = true
+	ts = l.ScanAll()
+	return ts, l.incomplete
+}
+
+// All returns an iterator over the remaining tokens and their positions,
+// up to but excluding [token.EOF].
+//
+// If the loop body stops early, the lexer is left right after the last
+// yielded token, so scanning may be resumed with [Lexer.Scan] or another
+// call of All.
+func (l *Lexer) All() iter.Seq2[token.Pos, TokenInfo] {
+	return func(yield func(token.Pos, TokenInfo) bool) {
+		for {
+			t := l.scanInfo()
+			if t.Tok == token.EOF || !yield(t.Pos, t) {
+				return
+			}
+		}
+	}
+}
+
+// ScanAll scans the remaining tokens up to but excluding [token.EOF].
+func (l *Lexer) ScanAll() TokenStream {
+	var ts TokenStream
+	for _, t := range l.All() {
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+// scanInfo scans the next token and returns it with its end position.
+func (l *Lexer) scanInfo() TokenInfo {
+	pos, tok, lit := l.Scan()
+
+	end := pos
+	if tok != token.EOF && (tok != token.Semicolon || lit != "\n") {
+		end = l.file.Pos(l.offset)
+	}
+	return TokenInfo{
+		Pos: pos,
+		End: end,
+		Tok: tok,
+		Lit: lit,
+	}
+}
+
+// Splice returns a new stream where tokens ts[i:j] are replaced by repl.
+// The original stream is not modified.
+// Positions of the inserted tokens are kept as is, use [TokenStream.Relex]
+// to obtain consistent positions.
+func (ts TokenStream) Splice(i, j int, repl ...TokenInfo) TokenStream {
+	return slices.Concat(ts[:i], repl, ts[j:])
+}
+
+// Source returns the source text of the stream.
+//
+// Tokens are separated by a single space, implicit semicolons are
+// written as newlines. The original layout is not preserved,
+// but scanning the result yields the same sequence of tokens.
+func (ts TokenStream) Source() []byte {
+	var buf bytes.Buffer
+	for i, t := range ts {
+		if t.IsImplicit() {
+			buf.WriteByte('\n')
+			continue
+		}
+
+		if i > 0 && !ts[i-1].IsImplicit() {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(t.Text())
+
+		// A //-style comment is terminated by a newline only.
+		if t.Tok == token.Comment && t.Lit[1] == '/' &&
+			(i+1 == len(ts) || !ts[i+1].IsImplicit()) {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
+
+// Relex converts the stream to source text, adds it as a new file to fset
+// and scans it again, so all tokens get consistent positions.
+func (ts TokenStream) Relex(fset *token.FileSet, filename string, err ErrorHandler) TokenStream {
+	src := ts.Source()
+	file := fset.AddFile(filename, fset.Base(), len(src))
+	return Tokenize(file, src, err)
+}
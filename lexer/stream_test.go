@@ -0,0 +1,133 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stable-lang/stlang/token"
+)
+
+func TestTokenStream(t *testing.T) {
+	const src = "package p\n\n// doc\nfunc f(a, b int) { /* x\n*/ return a ++ b }\n"
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("a.st", fset.Base(), len(src))
+	ts := Tokenize(file, []byte(src), func(pos token.Position, msg string) {
+		t.Errorf("%s: %s", pos, msg)
+	})
+
+	for _, tok := range ts {
+		if tok.IsImplicit() {
+			if tok.End != tok.Pos {
+				t.Errorf("implicit %s at %s: have end %d, want %d", tok.Tok, fset.Position(tok.Pos), tok.End, tok.Pos)
+			}
+			continue
+		}
+		offs, end := file.Offset(tok.Pos), file.Offset(tok.End)
+		if have := src[offs:end]; have != tok.Text() {
+			t.Errorf("token %s at %s: have text %q, want %q", tok.Tok, fset.Position(tok.Pos), have, tok.Text())
+		}
+	}
+
+	relexed := ts.Relex(fset, "b.st", func(pos token.Position, msg string) {
+		t.Errorf("%s: %s", pos, msg)
+	})
+	checkSameTokens(t, relexed, ts)
+
+	// replace "a ++ b" with "b".
+	var i int
+	for i = range ts {
+		if ts[i].Tok == token.Return {
+			break
+		}
+	}
+	spliced := ts.Splice(i+1, i+4, TokenInfo{Tok: token.Ident, Lit: "b"})
+	if len(spliced) != len(ts)-2 {
+		t.Fatalf("have %d tokens, want %d", len(spliced), len(ts)-2)
+	}
+	if ts[i+1].Lit != "a" {
+		t.Errorf("original stream was modified")
+	}
+	checkSameTokens(t, spliced.Relex(fset, "c.st", nil), spliced)
+}
+
+func checkSameTokens(t *testing.T, have, want TokenStream) {
+	t.Helper()
+
+	if len(have) != len(want) {
+		t.Fatalf("have %d tokens, want %d", len(have), len(want))
+	}
+	for i := range have {
+		if have[i].Tok != want[i].Tok || have[i].Lit != want[i].Lit {
+			t.Errorf("token %d: have %s %q, want %s %q", i, have[i].Tok, have[i].Lit, want[i].Tok, want[i].Lit)
+		}
+	}
+}
+
+func TestScanLine(t *testing.T) {
+	testCases := []struct {
+		src        string
+		incomplete bool
+	}{
+		{"a := 1", false},
+		{"f(a, b)", false},
+		{"func f() {", true},
+		{"f(a,\n", true},
+		{"x := [1, 2", true},
+		{"s := `foo\nbar", true},
+		{"s := \"\"\"\n\tfoo", true},
+		{"/* comment\n", true},
+		{"}}", false},
+	}
+
+	for _, tc := range testCases {
+		fset := token.NewFileSet()
+		file := fset.AddFile("", fset.Base(), len(tc.src))
+		_, incomplete := ScanLine(file, []byte(tc.src), func(pos token.Position, msg string) {
+			t.Errorf("%q: unexpected error %s: %s", tc.src, pos, msg)
+		})
+		if incomplete != tc.incomplete {
+			t.Errorf("%q: have incomplete %t, want %t", tc.src, incomplete, tc.incomplete)
+		}
+	}
+
+	// hard errors are still reported.
+	const src = "s := \"foo\n"
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var errs ErrorList
+	if _, incomplete := ScanLine(file, []byte(src), errs.Add); incomplete || errs.Len() != 1 {
+		t.Errorf("%q: have incomplete %t and errors %v, want one error", src, incomplete, errs)
+	}
+}
+
+func TestLexerAll(t *testing.T) {
+	const src = "a := b + c\n"
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	l := NewLexer(file, []byte(src), nil, 0)
+
+	var first []string
+	for pos, tok := range l.All() {
+		if pos != tok.Pos {
+			t.Errorf("%s: have pos %d, want %d", tok.Tok, pos, tok.Pos)
+		}
+		first = append(first, tok.Text())
+		if tok.Tok == token.Add {
+			break
+		}
+	}
+	if have := strings.Join(first, " "); have != "a := b +" {
+		t.Errorf("have %q before stopping, want %q", have, "a := b +")
+	}
+
+	// scanning resumes after the last yielded token.
+	rest := l.ScanAll()
+	if len(rest) != 2 || rest[0].Lit != "c" || !rest[1].IsImplicit() {
+		t.Errorf("have rest %v, want c and ;", rest)
+	}
+	if _, tok, _ := l.Scan(); tok != token.EOF {
+		t.Errorf("have %s, want EOF", tok)
+	}
+}
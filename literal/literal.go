@@ -0,0 +1,292 @@
+// Package literal implements conversions between Stable basic literals and their values.
+//
+// The escape sequences in string and rune literals are the ones accepted by the lexer:
+// \a \b \f \n \r \t \v \\, the enclosing quote, \ooo (octal), \xhh, \uhhhh and \Uhhhhhhhh.
+//
+// A multi-line string literal """...""" may contain newlines and the same escapes
+// as a "..." literal; it ends with the last """ of a run of quotes, so its body
+// may end in a quote. Its value is computed from the source text as follows:
+// if the first line, which follows the opening """, is blank it is removed,
+// as is a blank last line preceding the closing """. The longest run of spaces
+// and tabs common to the start of all remaining non-blank lines, except the first
+// line if kept, is removed from each line; blank lines become empty.
+// Escape sequences are interpreted afterwards.
+package literal
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrSyntax indicates that a literal does not have the right syntax.
+var ErrSyntax = errors.New("invalid syntax")
+
+// FloatPrec is the precision of floats returned by [ParseFloat].
+const FloatPrec = 512
+
+// Unquote interprets lit as a quoted string literal "...", a multi-line string
+// literal """...""", a raw string literal `...`, or a rune literal '...',
+// returning the string value that lit quotes.
+// A rune literal yields a string with a single character.
+func Unquote(lit string) (string, error) {
+	n := len(lit)
+	if n < 2 {
+		return "", ErrSyntax
+	}
+
+	if n >= 6 && strings.HasPrefix(lit, `"""`) && strings.HasSuffix(lit, `"""`) {
+		return unquoteMultiline(lit[3 : n-3])
+	}
+
+	quote := lit[0]
+	if quote != lit[n-1] {
+		return "", ErrSyntax
+	}
+	body := lit[1 : n-1]
+
+	switch quote {
+	case '`':
+		if strings.IndexByte(body, '`') >= 0 {
+			return "", ErrSyntax
+		}
+		// the lexer strips carriage returns from raw strings.
+		if strings.IndexByte(body, '\r') >= 0 {
+			return strings.ReplaceAll(body, "\r", ""), nil
+		}
+		return body, nil
+
+	case '"', '\'':
+		if strings.IndexByte(body, '\n') >= 0 {
+			return "", ErrSyntax
+		}
+		// fast path: no escapes
+		if strings.IndexByte(body, '\\') < 0 && strings.IndexByte(body, quote) < 0 {
+			if quote == '\'' && utf8.RuneCountInString(body) != 1 {
+				return "", ErrSyntax
+			}
+			return body, nil
+		}
+
+		var b strings.Builder
+		b.Grow(len(body))
+		chars := 0
+		for body != "" {
+			r, multibyte, tail, err := strconv.UnquoteChar(body, quote)
+			if err != nil {
+				return "", ErrSyntax
+			}
+			body = tail
+			chars++
+
+			// \xhh and \ooo escapes denote single bytes in strings.
+			if r < utf8.RuneSelf || !multibyte && quote == '"' {
+				b.WriteByte(byte(r))
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		if quote == '\'' && chars != 1 {
+			return "", ErrSyntax
+		}
+		return b.String(), nil
+
+	default:
+		return "", ErrSyntax
+	}
+}
+
+// unquoteMultiline returns the value of the body of a """...""" literal.
+func unquoteMultiline(body string) (string, error) {
+	body = strings.ReplaceAll(body, "\r", "")
+
+	lines := strings.Split(body, "\n")
+	first := true // lines[0] follows the opening delimiter
+	if len(lines) > 1 && isBlank(lines[0]) {
+		lines, first = lines[1:], false
+	}
+	if n := len(lines); n > 1 && isBlank(lines[n-1]) {
+		lines = lines[:n-1]
+	}
+
+	indent, found := "", false
+	for i, line := range lines {
+		if i == 0 && first || isBlank(line) {
+			continue
+		}
+		ws := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		switch {
+		case !found:
+			indent, found = ws, true
+		case !strings.HasPrefix(ws, indent):
+			indent = commonPrefix(indent, ws)
+		}
+	}
+	for i, line := range lines {
+		switch {
+		case isBlank(line):
+			lines[i] = ""
+		case i > 0 || !first:
+			lines[i] = line[len(indent):]
+		}
+	}
+	body = strings.Join(lines, "\n")
+
+	if strings.IndexByte(body, '\\') < 0 {
+		return body, nil
+	}
+	var b strings.Builder
+	b.Grow(len(body))
+	for body != "" {
+		// quotes and newlines need no escaping here.
+		if c := body[0]; c == '"' || c == '\n' {
+			b.WriteByte(c)
+			body = body[1:]
+			continue
+		}
+		r, multibyte, tail, err := strconv.UnquoteChar(body, '"')
+		if err != nil {
+			return "", ErrSyntax
+		}
+		body = tail
+		if r < utf8.RuneSelf || !multibyte {
+			b.WriteByte(byte(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), nil
+}
+
+func isBlank(line string) bool {
+	return strings.TrimLeft(line, " \t") == ""
+}
+
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return a[:i]
+}
+
+// UnquoteChar interprets lit as a rune literal '...' and returns the rune value.
+func UnquoteChar(lit string) (rune, error) {
+	n := len(lit)
+	if n < 3 || lit[0] != '\'' || lit[n-1] != '\'' {
+		return 0, ErrSyntax
+	}
+
+	r, _, tail, err := strconv.UnquoteChar(lit[1:n-1], '\'')
+	if err != nil || tail != "" {
+		return 0, ErrSyntax
+	}
+	return r, nil
+}
+
+// Quote returns a double-quoted string literal representing s.
+// Non-printable characters are written as escape sequences.
+func Quote(s string) string {
+	return strconv.Quote(s)
+}
+
+// QuoteRune returns a single-quoted rune literal representing r.
+func QuoteRune(r rune) string {
+	return strconv.QuoteRune(r)
+}
+
+// ParseInt interprets lit as an integer literal and returns its value.
+// Literals may use the 0b, 0o and 0x prefixes and '_' digit separators;
+// a leading zero without a prefix does not denote an octal literal.
+func ParseInt(lit string) (*big.Int, error) {
+	base, digits := splitBase(lit)
+	digits, ok := stripSeparators(digits, base != 10)
+	// big.Int accepts a sign, integer literals have none.
+	if !ok || digits == "" || strings.ContainsAny(digits, "+-") {
+		return nil, ErrSyntax
+	}
+
+	x, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, ErrSyntax
+	}
+	return x, nil
+}
+
+// ParseFloat interprets lit as a decimal float literal, such as 3.14 or 1_000.5,
+// and returns its value with [FloatPrec] bits of precision.
+func ParseFloat(lit string) (*big.Float, error) {
+	intPart, fracPart, ok := strings.Cut(lit, ".")
+	if !ok || fracPart == "" {
+		return nil, ErrSyntax
+	}
+
+	intPart, ok1 := stripSeparators(intPart, false)
+	fracPart, ok2 := stripSeparators(fracPart, false)
+	if !ok1 || !ok2 || !isDecimals(intPart) || !isDecimals(fracPart) {
+		return nil, ErrSyntax
+	}
+
+	x, _, err := big.ParseFloat(intPart+"."+fracPart, 10, FloatPrec, big.ToNearestEven)
+	if err != nil {
+		return nil, ErrSyntax
+	}
+	return x, nil
+}
+
+// splitBase returns the base of an integer literal and its digits without prefix.
+func splitBase(lit string) (int, string) {
+	if len(lit) >= 2 && lit[0] == '0' {
+		switch lit[1] {
+		case 'b', 'B':
+			return 2, lit[2:]
+		case 'o', 'O':
+			return 8, lit[2:]
+		case 'x', 'X':
+			return 16, lit[2:]
+		}
+	}
+	return 10, lit
+}
+
+// stripSeparators removes '_' separators from digits.
+// A separator must be placed between digits; after a base prefix,
+// a leading separator is permitted, e.g. 0x_ff.
+func stripSeparators(digits string, prefixed bool) (string, bool) {
+	if strings.IndexByte(digits, '_') < 0 {
+		return digits, true
+	}
+
+	var b strings.Builder
+	b.Grow(len(digits))
+	prev := byte('0')
+	if !prefixed {
+		prev = '_' // no leading separator
+	}
+	for i := 0; i < len(digits); i++ {
+		c := digits[i]
+		if c == '_' {
+			if prev == '_' {
+				return "", false
+			}
+		} else {
+			b.WriteByte(c)
+		}
+		prev = c
+	}
+	if prev == '_' {
+		return "", false
+	}
+	return b.String(), true
+}
+
+func isDecimals(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,197 @@
+package literal
+
+import (
+	"testing"
+)
+
+func TestUnquote(t *testing.T) {
+	testCases := []struct {
+		lit  string
+		want string
+		ok   bool
+	}{
+		{`""`, "", true},
+		{`"abc"`, "abc", true},
+		{`"ŝfoo"`, "ŝfoo", true},
+		{`"a\tb\n"`, "a\tb\n", true},
+		{`"\"\\"`, "\"\\", true},
+		{`"\000\x7f\xff"`, "\x00\x7f\xff", true},
+		{`"é\U0001F600"`, "é😀", true},
+		{"`raw\\n`", `raw\n`, true},
+		{"`a\r\nb`", "a\nb", true},
+		{`'a'`, "a", true},
+		{`'\''`, "'", true},
+		{`'\xff'`, "ÿ", true},
+		{`'é'`, "é", true},
+
+		{`"`, "", false},
+		{`"abc`, "", false},
+		{`"a"b"`, "", false},
+		{`"\'"`, "", false},
+		{`'\"'`, "", false},
+		{`"\q"`, "", false},
+		{`"\uD800"`, "", false},
+		{"\"a\nb\"", "", false},
+		{`''`, "", false},
+		{`'ab'`, "", false},
+		{"`a`b`", "", false},
+		{`abc`, "", false},
+	}
+
+	for _, tc := range testCases {
+		have, err := Unquote(tc.lit)
+		switch {
+		case (err == nil) != tc.ok:
+			t.Errorf("Unquote(%s): have error %v, want ok %t", tc.lit, err, tc.ok)
+		case have != tc.want:
+			t.Errorf("Unquote(%s) = %q, want %q", tc.lit, have, tc.want)
+		}
+	}
+}
+
+func TestUnquoteChar(t *testing.T) {
+	testCases := []struct {
+		lit  string
+		want rune
+		ok   bool
+	}{
+		{`'a'`, 'a', true},
+		{`'\n'`, '\n', true},
+		{`'\000'`, 0, true},
+		{`'\377'`, 0xff, true},
+		{`'６'`, '６', true},
+		{`'\U0000ff16'`, '６', true},
+
+		{`'\400'`, 0, false},
+		{`'ab'`, 0, false},
+		{`'\U00110000'`, 0, false},
+		{`"a"`, 0, false},
+	}
+
+	for _, tc := range testCases {
+		have, err := UnquoteChar(tc.lit)
+		switch {
+		case (err == nil) != tc.ok:
+			t.Errorf("UnquoteChar(%s): have error %v, want ok %t", tc.lit, err, tc.ok)
+		case have != tc.want:
+			t.Errorf("UnquoteChar(%s) = %q, want %q", tc.lit, have, tc.want)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for _, s := range []string{"", "abc", "a\tb\n\"c\"", "é😀", "\x00\xff"} {
+		q := Quote(s)
+		have, err := Unquote(q)
+		if err != nil || have != s {
+			t.Errorf("Unquote(Quote(%q)) = %q, %v", s, have, err)
+		}
+	}
+
+	if have := QuoteRune('\''); have != `'\''` {
+		t.Errorf("QuoteRune = %s, want '\\''", have)
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	testCases := []struct {
+		lit  string
+		want string
+		ok   bool
+	}{
+		{"0", "0", true},
+		{"12345", "12345", true},
+		{"000123", "123", true},
+		{"01234567", "1234567", true},
+		{"1_000_000", "1000000", true},
+		{"0b1010", "10", true},
+		{"0o777", "511", true},
+		{"0xcafe_babe", "3405691582", true},
+		{"0x_ff", "255", true},
+		{"123456789012345678890", "123456789012345678890", true},
+
+		{"", "", false},
+		{"0x", "", false},
+		{"_1", "", false},
+		{"1_", "", false},
+		{"1__0", "", false},
+		{"0b102", "", false},
+		{"12a", "", false},
+		{"-5", "", false},
+		{"+5", "", false},
+		{"0x-1", "", false},
+	}
+
+	for _, tc := range testCases {
+		have, err := ParseInt(tc.lit)
+		switch {
+		case (err == nil) != tc.ok:
+			t.Errorf("ParseInt(%s): have error %v, want ok %t", tc.lit, err, tc.ok)
+		case err == nil && have.String() != tc.want:
+			t.Errorf("ParseInt(%s) = %s, want %s", tc.lit, have, tc.want)
+		}
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	testCases := []struct {
+		lit  string
+		want float64
+		ok   bool
+	}{
+		{"0.0", 0, true},
+		{"3.14159265", 3.14159265, true},
+		{"1_000.000_5", 1000.0005, true},
+		{"000.5", 0.5, true},
+
+		{"1", 0, false},
+		{"1.", 0, false},
+		{".5", 0, false},
+		{"1.5e3", 0, false},
+		{"1_.5", 0, false},
+		{"0x1.0", 0, false},
+	}
+
+	for _, tc := range testCases {
+		have, err := ParseFloat(tc.lit)
+		switch {
+		case (err == nil) != tc.ok:
+			t.Errorf("ParseFloat(%s): have error %v, want ok %t", tc.lit, err, tc.ok)
+		case err == nil:
+			if f, _ := have.Float64(); f != tc.want {
+				t.Errorf("ParseFloat(%s) = %v, want %v", tc.lit, f, tc.want)
+			}
+		}
+	}
+}
+
+func TestUnquoteMultiline(t *testing.T) {
+	testCases := []struct {
+		lit  string
+		want string
+		ok   bool
+	}{
+		{`""""""`, "", true},
+		{`"""abc"""`, "abc", true},
+		{`"""  abc"""`, "  abc", true},
+		{`"""say "hi""""`, `say "hi"`, true},
+		{"\"\"\"\n\tfoo\n\t\tbar\n\t\"\"\"", "foo\n\tbar", true},
+		{"\"\"\"\n    a\n\n      b\n    \"\"\"", "a\n\n  b", true},
+		{"\"\"\"first\n  a\n  b\"\"\"", "first\na\nb", true},
+		{"\"\"\"\r\n  a\r\n  b\r\n\"\"\"", "a\nb", true},
+		{"\"\"\"\n  \\t\"q\" \\u00e9\n  \"\"\"", "\t\"q\" é", true},
+		{"\"\"\"\n \ta\n \tb\n  c\n\"\"\"", "\ta\n\tb\n c", true},
+
+		{"\"\"\"\n  \\q\n\"\"\"", "", false},
+	}
+
+	for _, tc := range testCases {
+		have, err := Unquote(tc.lit)
+		switch {
+		case (err == nil) != tc.ok:
+			t.Errorf("Unquote(%q): have error %v, want ok %t", tc.lit, err, tc.ok)
+		case have != tc.want:
+			t.Errorf("Unquote(%q) = %q, want %q", tc.lit, have, tc.want)
+		}
+	}
+}
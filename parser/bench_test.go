@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stable-lang/stlang/lexer"
+	"github.com/stable-lang/stlang/token"
+)
+
+// allocsPerToken is the allocation budget of ParseFile,
+// measured on the medium benchmark source. ParseFile took
+// 0.95 allocations per token when it was set (go1.27, amd64);
+// the rest is headroom.
+const allocsPerToken = 1.0
+
+var benchSources = []struct {
+	name  string
+	decls int
+}{
+	{"small", 10},
+	{"medium", 500},
+	{"huge", 20000},
+}
+
+// benchSource returns a source file with n groups of declarations.
+func benchSource(n int) []byte {
+	var b strings.Builder
+	b.WriteString("// Package bench is a generated benchmark source.\npackage bench\n\n")
+	b.WriteString("import \"fmt\"\nimport str \"strings\"\n\n")
+	for i := range n {
+		fmt.Fprintf(&b, "// C%d is a constant.\nconst C%d int = value%d\n\n", i, i, i)
+		fmt.Fprintf(&b, "var v%d = C%d // line comment\n\n", i, i)
+		fmt.Fprintf(&b, "/*\n * T%d is a type.\n */\ntypedef T%d = pkg.Type%d\n\n", i, i, i)
+		fmt.Fprintf(&b, "// S%d is a struct.\nstruct S%d {\n\tA, B int // fields\n\tC str.Builder\n}\n\n", i, i)
+		fmt.Fprintf(&b, "func (S%d) f%d(a int, b bool) (int, bool) {}\n\n", i, i)
+	}
+	return []byte(b.String())
+}
+
+func countTokens(src []byte) int {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	return len(lexer.Tokenize(file, src, nil))
+}
+
+func BenchmarkParseFile(b *testing.B) {
+	for _, bs := range benchSources {
+		src := benchSource(bs.decls)
+
+		b.Run(bs.name, func(b *testing.B) {
+			b.SetBytes(int64(len(src)))
+			b.ReportAllocs()
+
+			for range b.N {
+				fset := token.NewFileSet()
+				if _, err := ParseFile(fset, "", src); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseFileAllocs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	src := benchSource(benchSources[1].decls)
+	tokens := countTokens(src)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		fset := token.NewFileSet()
+		if _, err := ParseFile(fset, "", src); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if have := allocs / float64(tokens); have > allocsPerToken {
+		t.Errorf("have %.3f allocs per token (%.0f allocs, %d tokens), want at most %.3f", have, allocs, tokens, allocsPerToken)
+	}
+}
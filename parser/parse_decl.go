@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"slices"
+
 	"github.com/stable-lang/stlang/ast"
 	"github.com/stable-lang/stlang/token"
 )
@@ -151,8 +153,9 @@ func (p *parser) parseStructDecl() *ast.StructDecl {
 	name := p.parseIdent()
 
 	leftBrace := p.expect(token.LeftBrace)
-	var list []*ast.Field
-	for p.tok == token.Ident {
+	var buf [8]ast.Field // most structs are small
+	list := buf[:0]
+	for p.tok == token.Ident || p.tok.IsKeyword() {
 		list = append(list, p.parseFieldDecl())
 	}
 	rightBrace := p.expect(token.RightBrace)
@@ -160,13 +163,9 @@ func (p *parser) parseStructDecl() *ast.StructDecl {
 	p.expectSemi()
 
 	return &ast.StructDecl{
-		Doc:  doc,
-		Name: name,
-		Fields: &ast.FieldList{
-			Opening: leftBrace,
-			List:    list,
-			Closing: rightBrace,
-		},
+		Doc:    doc,
+		Name:   name,
+		Fields: newFieldList(leftBrace, list, rightBrace),
 	}
 }
 
@@ -214,20 +213,20 @@ func (p *parser) parseVarDecl() *ast.VarDecl {
 	}
 }
 
-func (p *parser) parseFieldDecl() *ast.Field {
+func (p *parser) parseFieldDecl() ast.Field {
 	doc := p.leadComment
 
 	var names []*ast.Ident
 	var typ ast.Expr
 
-	if p.tok == token.Ident {
-		name := p.parseIdent()
-
-		names = []*ast.Ident{name}
+	if p.tok == token.Ident || p.tok.IsKeyword() {
+		var buf [4]*ast.Ident
+		list := append(buf[:0], p.parseFieldName())
 		for p.tok == token.Comma {
 			p.next()
-			names = append(names, p.parseIdent())
+			list = append(list, p.parseFieldName())
 		}
+		names = slices.Clone(list)
 		typ = p.parseType()
 	} else {
 		pos := p.pos
@@ -238,7 +237,7 @@ func (p *parser) parseFieldDecl() *ast.Field {
 
 	comment := p.expectSemi()
 
-	return &ast.Field{
+	return ast.Field{
 		Doc:     doc,
 		Names:   names,
 		Type:    typ,
@@ -249,37 +248,140 @@ func (p *parser) parseFieldDecl() *ast.Field {
 func (p *parser) parseParameters() (params *ast.FieldList) {
 	leftParen := p.expect(token.LeftParen)
 
-	var fields []*ast.Field
+	var buf [8]ast.Field // most parameter lists are short
+	fields := buf[:0]
 	if p.tok != token.RightParen {
-		fields = p.parseParameterList()
+		fields = p.parseParameterList(fields)
 	}
 
 	rightParen := p.expect(token.RightParen)
 
-	return &ast.FieldList{
-		Opening: leftParen,
-		List:    fields,
-		Closing: rightParen,
-	}
+	return newFieldList(leftParen, fields, rightParen)
 }
 
-func (p *parser) parseParameterList() []*ast.Field {
-	var params []*ast.Field
-	for p.tok != token.RightParen {
+// parseParameterList parses parameters up to the closing ')'
+// and appends them to params.
+// A trailing comma before ')' is permitted.
+func (p *parser) parseParameterList(params []ast.Field) []ast.Field {
+	type param struct {
+		name *ast.Ident
+		typ  ast.Expr
+	}
+
+	var buf [8]param // most parameter lists are short
+	list := buf[:0]
+	named := false // set if any parameter has both name and type
+	for p.tok != token.RightParen && p.tok != token.EOF {
+		var par param
+		if p.tok == token.Ident {
+			ident := p.parseIdent()
+			switch p.tok {
+			case token.Comma, token.RightParen:
+				// name or type, decided below
+				par.typ = ident
+			case token.Question:
+				par.typ = p.parseOptionalType(ident)
+			case token.Period:
+				par.typ = p.parseOptionalType(p.parseTypeName(ident))
+			default:
+				par.name = ident
+				par.typ = p.parseParameterType()
+				named = true
+			}
+		} else {
+			par.typ = p.parseParameterType()
+		}
+		list = append(list, par)
+
+		if p.tok != token.Comma {
+			break
+		}
 		p.next()
 	}
+
+	if !named {
+		// types only
+		for _, par := range list {
+			params = append(params, ast.Field{Type: par.typ})
+		}
+		return params
+	}
+
+	// names followed by a type, e.g. (a, b int, c bool);
+	// the names of all parameters share one array.
+	names := make([]*ast.Ident, 0, len(list))
+	start := 0 // names of the current parameter
+	for _, par := range list {
+		if par.name == nil {
+			name, ok := par.typ.(*ast.Ident)
+			if !ok {
+				p.error(par.typ.Pos(), "mixed named and unnamed parameters")
+				params = append(params, ast.Field{Type: par.typ})
+				continue
+			}
+			names = append(names, name)
+			continue
+		}
+
+		names = append(names, par.name)
+		params = append(params, ast.Field{Names: names[start:len(names):len(names)], Type: par.typ})
+		start = len(names)
+	}
+	if len(names) > start {
+		p.error(names[start].Pos(), "missing parameter type")
+		params = append(params, ast.Field{Names: names[start:]})
+	}
 	return params
 }
 
+func (p *parser) parseParameterType() ast.Expr {
+	if p.tok == token.Ellipsis {
+		pos := p.pos
+		p.next()
+		return &ast.Ellipsis{
+			Ellipsis: pos,
+			ElemType: p.parseType(),
+		}
+	}
+	return p.parseType()
+}
+
 func (p *parser) parseResult() *ast.FieldList {
 	if p.tok == token.LeftParen {
 		return p.parseParameters()
 	}
 
 	if typ := p.tryIdentOrType(); typ != nil {
-		return &ast.FieldList{
-			List: []*ast.Field{{Type: typ}},
-		}
+		return newFieldList(token.NoPos, []ast.Field{{Type: typ}}, token.NoPos)
 	}
 	return nil
 }
+
+// singleFieldList holds a field list of one field,
+// so that the list, its slice and the field are allocated together.
+type singleFieldList struct {
+	list  ast.FieldList
+	ptr   [1]*ast.Field
+	field ast.Field
+}
+
+// newFieldList returns a field list with copies of the fields in list.
+func newFieldList(opening token.Pos, list []ast.Field, closing token.Pos) *ast.FieldList {
+	switch len(list) {
+	case 0:
+		return &ast.FieldList{Opening: opening, Closing: closing}
+	case 1:
+		f := &singleFieldList{field: list[0]}
+		f.ptr[0] = &f.field
+		f.list = ast.FieldList{Opening: opening, List: f.ptr[:], Closing: closing}
+		return &f.list
+	}
+
+	block := make([]ast.Field, len(list))
+	copy(block, list)
+	ptrs := make([]*ast.Field, len(list))
+	for i := range block {
+		ptrs[i] = &block[i]
+	}
+	return &ast.FieldList{Opening: opening, List: ptrs, Closing: closing}
+}
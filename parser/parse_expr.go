@@ -21,6 +21,20 @@ func (p *parser) parseIdent() *ast.Ident {
 	}
 }
 
+// parseFieldName parses a struct field name.
+// Keywords are permitted as field names, e.g. "case int".
+func (p *parser) parseFieldName() *ast.Ident {
+	if p.tok.IsKeyword() {
+		ident := &ast.Ident{
+			NamePos: p.pos,
+			Name:    p.tok.String(),
+		}
+		p.next()
+		return ident
+	}
+	return p.parseIdent()
+}
+
 // types
 
 func (p *parser) parseType() ast.Expr {
@@ -38,13 +52,35 @@ func (p *parser) parseType() ast.Expr {
 }
 
 func (p *parser) tryIdentOrType() ast.Expr {
+	typ := p.tryNonOptionalType()
+	if typ != nil {
+		typ = p.parseOptionalType(typ)
+	}
+	return typ
+}
+
+// parseOptionalType wraps typ into an OptionalType if it is followed by '?'.
+func (p *parser) parseOptionalType(typ ast.Expr) ast.Expr {
+	if p.tok != token.Question {
+		return typ
+	}
+	optional := &ast.OptionalType{
+		X:        typ,
+		Question: p.pos,
+	}
+	p.next()
+	return optional
+}
+
+func (p *parser) tryNonOptionalType() ast.Expr {
 	switch p.tok {
 	case token.Any, token.Bool, token.Void:
-		p.next()
-		return &ast.Ident{
+		ident := &ast.Ident{
 			NamePos: p.pos,
 			Name:    p.tok.String(),
 		}
+		p.next()
+		return ident
 	case token.Ident:
 		return p.parseTypeName(nil)
 	default:
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stable-lang/stlang/token"
+)
+
+func TestParseDir(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.st", "package p\n")
+	write("b.st", "package !\n")
+	write("notes.txt", "not a source file\n")
+
+	overlay := Overlay{
+		dir + "//b.st":             []byte("package p\nconst b = a\n"), // not clean
+		filepath.Join(dir, "c.st"): []byte("package p\n"),
+		"elsewhere/d.st":           []byte("package q\n"),
+	}
+
+	fset := token.NewFileSet()
+	files, err := ParseDir(fset, dir, overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, f := range files {
+		names = append(names, filepath.Base(fset.File(f.Pos()).Name()))
+	}
+	if have, want := names, []string{"a.st", "b.st", "c.st"}; !slices.Equal(have, want) {
+		t.Errorf("have files %v, want %v", have, want)
+	}
+	if have := len(files[1].Decls); have != 1 {
+		t.Errorf("b.st: have %d decls, want 1 from the overlay", have)
+	}
+
+	// without the overlay the file on disk is parsed.
+	files, err = ParseDir(token.NewFileSet(), dir, nil)
+	if err == nil || len(files) != 2 {
+		t.Errorf("have %d files and error %v, want 2 files and an error", len(files), err)
+	}
+}
@@ -7,16 +7,52 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/stable-lang/stlang/ast"
 	"github.com/stable-lang/stlang/lexer"
 	"github.com/stable-lang/stlang/token"
 )
 
+// Ext is the file name extension of Stable source files.
+const Ext = ".st"
+
+// Overlay maps file names to contents that replace the files on disk,
+// such as unsaved editor buffers. File names are compared after [filepath.Clean].
+type Overlay map[string][]byte
+
+// clean returns a copy of the overlay with cleaned file names.
+func (o Overlay) clean() Overlay {
+	c := make(Overlay, len(o))
+	for name, src := range o {
+		c[filepath.Clean(name)] = src
+	}
+	return c
+}
+
+// read returns the overlay contents of the file, if any.
+// The overlay must have been cleaned.
+func (o Overlay) read(filename string) ([]byte, bool) {
+	src, ok := o[filepath.Clean(filename)]
+	return src, ok
+}
+
 // ParseFile of a single Stable source file and returns the corresponding [ast.File] node.
 // The source code may be provided via the filename of the source file, or via the src parameter.
-func ParseFile(fset *token.FileSet, filename string, src any) (f *ast.File, err error) {
+// If src is an [Overlay], the file is read from the overlay or, if not found there, from disk.
+func ParseFile(fset *token.FileSet, filename string, src any) (*ast.File, error) {
+	if overlay, ok := src.(Overlay); ok {
+		src = overlay.clean()
+	}
+	return parseFile(fset, filename, src)
+}
+
+// parseFile is like ParseFile, but expects an overlay to be cleaned already.
+func parseFile(fset *token.FileSet, filename string, src any) (f *ast.File, err error) {
 	if fset == nil {
 		panic("parser.ParseFile: no token.FileSet provided")
 	}
@@ -42,9 +78,9 @@ func ParseFile(fset *token.FileSet, filename string, src any) (f *ast.File, err
 
 		// Ensure the start/end are consistent, whether parsing succeeded or not.
 		f.FileStart = token.Pos(file.Base())
-		f.FileEnd = token.Pos(file.Base() + file.Size())
+		f.FileEnd = token.Pos(file.Base() + int64(file.Size()))
 
-		p.errors.sort()
+		p.errors.Sort()
 		err = p.errors.Err()
 	}()
 
@@ -54,9 +90,56 @@ func ParseFile(fset *token.FileSet, filename string, src any) (f *ast.File, err
 	return f, err
 }
 
+// ParseDir parses all Stable source files in the directory, taking the overlay into account.
+// Files present only in the overlay are parsed as well.
+// The files are returned sorted by name. If errors occur, the files parsed
+// so far are returned together with the errors.
+func ParseDir(fset *token.FileSet, path string, overlay Overlay) ([]*ast.File, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil && !(errors.Is(err, fs.ErrNotExist) && len(overlay) > 0) {
+		return nil, err
+	}
+
+	overlay = overlay.clean()
+
+	sources := make(map[string]bool) // cleaned file names
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), Ext) {
+			sources[filepath.Join(path, e.Name())] = true
+		}
+	}
+
+	dir := filepath.Clean(path)
+	for name := range overlay {
+		if filepath.Dir(name) == dir && strings.HasSuffix(name, Ext) {
+			sources[name] = true
+		}
+	}
+	names := slices.Sorted(maps.Keys(sources))
+
+	files := make([]*ast.File, 0, len(names))
+	var errs ErrorList
+	for _, name := range names {
+		f, err := parseFile(fset, name, overlay)
+		if err != nil {
+			list, ok := err.(ErrorList)
+			if !ok {
+				return files, err
+			}
+			errs = append(errs, list...)
+		}
+		files = append(files, f)
+	}
+	return files, errs.Err()
+}
+
 func readSource(filename string, src any) ([]byte, error) {
 	if src != nil {
 		switch src := src.(type) {
+		case Overlay:
+			if text, ok := src.read(filename); ok {
+				return text, nil
+			}
 		case string:
 			return []byte(src), nil
 		case []byte:
@@ -99,8 +182,7 @@ type parser struct {
 
 func (p *parser) init(file *token.File, src []byte) {
 	p.file = file
-	errFn := func(pos token.Position, msg string) { p.errors.Add(pos, msg) }
-	p.scanner = lexer.NewLexer(p.file, src, errFn)
+	p.scanner = lexer.NewLexer(p.file, src, p.errors.Add, 0)
 
 	p.next()
 }
@@ -203,12 +285,7 @@ func (p *parser) next() {
 
 // Advance to the next token.
 func (p *parser) next0() {
-	for {
-		p.pos, p.tok, p.lit = p.scanner.Scan()
-		if p.tok != token.Comment {
-			break
-		}
-	}
+	p.pos, p.tok, p.lit = p.scanner.Scan()
 }
 
 // Consume a group of adjacent comments, add it to the parser's
@@ -216,22 +293,49 @@ func (p *parser) next0() {
 // the last comment in the group ends. A non-comment token or n
 // empty lines terminate a comment group.
 func (p *parser) consumeCommentGroup(n int) (comments *ast.CommentGroup, endline int) {
-	var list []*ast.Comment
+	var buf [8]ast.Comment // most comment groups are short
+	list := buf[:0]
 	endline = p.file.Line(p.pos)
 	for p.tok == token.Comment && p.file.Line(p.pos) <= endline+n {
-		var comment *ast.Comment
+		var comment ast.Comment
 		comment, endline = p.consumeComment()
 		list = append(list, comment)
 	}
 
-	comments = &ast.CommentGroup{List: list}
+	comments = newCommentGroup(list)
 	p.comments = append(p.comments, comments)
 
 	return comments, endline
 }
 
+// singleCommentGroup holds a comment group of one comment,
+// so that the group, its list and the comment are allocated together.
+type singleCommentGroup struct {
+	group   ast.CommentGroup
+	list    [1]*ast.Comment
+	comment ast.Comment
+}
+
+// newCommentGroup returns a comment group with copies of the comments in list.
+func newCommentGroup(list []ast.Comment) *ast.CommentGroup {
+	if len(list) == 1 {
+		g := &singleCommentGroup{comment: list[0]}
+		g.list[0] = &g.comment
+		g.group.List = g.list[:]
+		return &g.group
+	}
+
+	block := make([]ast.Comment, len(list))
+	copy(block, list)
+	ptrs := make([]*ast.Comment, len(list))
+	for i := range block {
+		ptrs[i] = &block[i]
+	}
+	return &ast.CommentGroup{List: ptrs}
+}
+
 // Consume a comment and return it and the line on which it ends.
-func (p *parser) consumeComment() (comment *ast.Comment, endline int) {
+func (p *parser) consumeComment() (comment ast.Comment, endline int) {
 	// /*-style comments may end on a different line than where they start.
 	// Scan the comment for '\n' chars and adjust endline accordingly.
 	endline = p.file.Line(p.pos)
@@ -244,7 +348,7 @@ func (p *parser) consumeComment() (comment *ast.Comment, endline int) {
 		}
 	}
 
-	comment = &ast.Comment{
+	comment = ast.Comment{
 		Slash: p.pos,
 		Text:  p.lit,
 	}
@@ -27,9 +27,9 @@ func TestParseDecl(t *testing.T) {
 			{`package internal;`, "package name 'internal' is reserved"},
 			{`package vendor;`, "package name 'vendor' is reserved"},
 
-			{`package 123;`, `expected 'Ident', found 123`},
-			{`package 'a';`, `expected 'Ident', found 'a'`},
-			{`package "pkg";`, `expected 'Ident', found "pkg"`},
+			{`package 123;`, `expected 'IDENT', found 123`},
+			{`package 'a';`, `expected 'IDENT', found 'a'`},
+			{`package "pkg";`, `expected 'IDENT', found "pkg"`},
 		}
 
 		for _, tc := range testCases {
@@ -61,9 +61,21 @@ func TestParseDecl(t *testing.T) {
 			{`func fun() (foo,bar) {}`, ``},
 			{`func (R) foo(){}`, ``},
 			{`func f() {};`, ``},
+			{`func f(a int, b bool) {}`, ``},
+			{`func f(a, b int, c ...any) (int, bool) {}`, ``},
+			{`func f(a int, b bool,) (int, bool,) {}`, ``},
+			{"func f(\n\ta int,\n\tb bool,\n) {}", ``},
+			{`func f(int, pkg.T, ...bool) {}`, ``},
+			{`func f(a T?, b ...int?) S? {}`, ``},
+			{`func f(int?) {}`, ``},
+			{`func f(pkg.T?) {}`, ``},
+			{`func f() (int?, bool) {}`, ``},
 
 			{"func f()\n{};", `unexpected semicolon or newline before {`},
 			{"func f()\nfoo", `expected '{', found foo`},
+			{`func f(a int, b) {}`, `missing parameter type`},
+			{`func f(a int, pkg.T) {}`, `mixed named and unnamed parameters`},
+			{`func f(a int b bool) {}`, `expected ')', found b`},
 		}
 
 		for _, tc := range testCases {
@@ -98,6 +110,9 @@ func TestParseDecl(t *testing.T) {
 			{`struct foo{}`, ``},
 			{`struct _{}`, ``},
 			{`struct _{ A int }`, ``},
+			{"struct S {\n\tA bool\n\tB, C any\n}", ``},
+			{"struct S {\n\tA bool?\n\tB any?\n}", ``},
+			{"struct S {\n\tcase int\n\tany, func bool\n\tvoid pkg.T\n}", ``},
 
 			{`struct foo bar{}`, `expected '{', found bar`},
 		}
@@ -112,6 +127,9 @@ func TestParseDecl(t *testing.T) {
 			{`typedef foo bar`, ``},
 			{`typedef foo = bar`, ``},
 			{`typedef T = int`, ``},
+			{"typedef T = int?\n", ``},
+			{"typedef T pkg.any\n", ``},
+			{"typedef T = pkg.struct\n", ``},
 		}
 
 		for _, tc := range testCases {
@@ -124,6 +142,9 @@ func TestParseDecl(t *testing.T) {
 			{`var a = b;`, ``},
 			{`var a b = c;`, ``},
 			{`var a bool = empty;`, ``},
+			{"var a T? = b\n", ``},
+			{"var a pkg.T? = b\n", ``},
+			{"var a T?? = b\n", `expected '=', found '?'`},
 		}
 
 		for _, tc := range testCases {
@@ -140,7 +161,7 @@ func checkParse(t testing.TB, src, wantErr string) {
 	}
 
 	found := err.(ErrorList)
-	found.removeMultiples()
+	found.RemoveMultiples()
 
 	switch have := found.Error(); {
 	case wantErr == "":
@@ -7,9 +7,18 @@ import (
 // File represents a source file.
 type File struct {
 	name  string // file name as provided to AddFile
-	base  int    // Pos value range for this file is [base...base+size]
+	base  int64  // Pos value range for this file is [base...base+size]
 	size  int    // file size as provided to AddFile
 	lines []int  // lines contains the offset of the first character for each line (the first entry is always 0)
+	infos []lineInfo
+}
+
+// lineInfo describes alternative file, line, and column number information
+// (such as provided via a //line directive) for a given file offset.
+type lineInfo struct {
+	Offset       int
+	Filename     string
+	Line, Column int
 }
 
 // Name returns the file name of file f as registered with AddFile.
@@ -18,7 +27,7 @@ func (f *File) Name() string {
 }
 
 // Base returns the base offset of file f as registered with AddFile.
-func (f *File) Base() int {
+func (f *File) Base() int64 {
 	return f.base
 }
 
@@ -48,6 +57,20 @@ func (f *File) AddLine(offset int) {
 	}
 }
 
+// AddLineColumnInfo adds alternative file, line, and column number
+// information for a given file offset. The offset must be larger
+// than the offset for the previously added alternative line info
+// and smaller than the file size; otherwise the information is ignored.
+//
+// AddLineColumnInfo is typically used to register alternative position
+// information for line directives such as //line filename:line:column.
+// A column of 0 means the column is unknown.
+func (f *File) AddLineColumnInfo(offset int, filename string, line, column int) {
+	if i := len(f.infos); (i == 0 || f.infos[i-1].Offset < offset) && offset < f.size {
+		f.infos = append(f.infos, lineInfo{offset, filename, line, column})
+	}
+}
+
 // LineStart returns the position of the first character in the line.
 func (f *File) LineStart(line int) Pos {
 	switch {
@@ -58,18 +81,18 @@ func (f *File) LineStart(line int) Pos {
 		panic(fmt.Sprintf("invalid line number %d (should be < %d)", line, len(f.lines)))
 
 	default:
-		return Pos(f.base + f.lines[line-1])
+		return Pos(f.base + int64(f.lines[line-1]))
 	}
 }
 
 // FileSetPos returns the position in the file set.
 func (f *File) Pos(offset int) Pos {
-	return Pos(f.base + f.fixOffset(offset))
+	return Pos(f.base + int64(f.fixOffset(offset)))
 }
 
 // Offset returns the offset for the given file position p.
 func (f *File) Offset(p Pos) int {
-	return f.fixOffset(int(p) - f.base)
+	return f.fixOffset(f.relOffset(p))
 }
 
 // Line returns the line number for the given file position p.
@@ -77,23 +100,50 @@ func (f *File) Line(p Pos) int {
 	return f.Position(p).Line
 }
 
-// Position returns the position value for the given file position p.
+// PositionFor returns the position value for the given file position p.
 // If p is out of bounds, it is adjusted to match the File.Offset behavior.
-func (f *File) Position(p Pos) Position {
+// If adjusted is set, the position may be adjusted by position-altering
+// //line comments; otherwise those comments are ignored.
+func (f *File) PositionFor(p Pos, adjusted bool) Position {
 	if p == NoPos {
 		return Position{}
 	}
-	return f.position(p)
+	return f.position(p, adjusted)
+}
+
+// Position returns the position value for the given file position p.
+// Calling f.Position(p) is equivalent to calling f.PositionFor(p, true).
+func (f *File) Position(p Pos) Position {
+	return f.PositionFor(p, true)
 }
 
-func (f *File) position(p Pos) Position {
-	offset := f.fixOffset(int(p) - f.base)
+func (f *File) position(p Pos, adjusted bool) Position {
+	offset := f.fixOffset(f.relOffset(p))
 	var pos Position
 	pos.Offset = offset
-	pos.Filename, pos.Line, pos.Column = f.unpack(offset)
+	pos.Filename, pos.Line, pos.Column = f.unpack(offset, adjusted)
 	return pos
 }
 
+// relOffset returns the offset of p relative to the file base,
+// clamped to the int range so it can be fixed with fixOffset.
+func (f *File) relOffset(p Pos) int {
+	offset := int64(p) - f.base
+	switch {
+	case offset < 0:
+		return -1
+	case offset > int64(f.size):
+		return f.size + 1
+	default:
+		return int(offset)
+	}
+}
+
+// contains reports whether p is in the Pos range of the file.
+func (f *File) contains(p Pos) bool {
+	return f.base <= int64(p) && int64(p) <= f.base+int64(f.size)
+}
+
 // fixOffset fixes an out-of-bounds offset such that 0 <= offset <= f.size.
 func (f *File) fixOffset(offset int) int {
 	switch {
@@ -107,14 +157,49 @@ func (f *File) fixOffset(offset int) int {
 }
 
 // unpack returns the filename, line, column number for a file offset.
-func (f *File) unpack(offset int) (filename string, line, column int) {
+// If adjusted is set, alternative line infos are taken into account.
+func (f *File) unpack(offset int, adjusted bool) (filename string, line, column int) {
 	filename = f.name
 	if i := searchInts(f.lines, offset); i >= 0 {
 		line, column = i+1, offset-f.lines[i]+1
 	}
+	if adjusted && len(f.infos) > 0 {
+		// few files have extra line infos
+		if i := searchLineInfos(f.infos, offset); i >= 0 {
+			alt := &f.infos[i]
+			filename = alt.Filename
+			if i := searchInts(f.lines, alt.Offset); i >= 0 {
+				// i+1 is the line at which the alternative position was recorded
+				d := line - (i + 1) // line distance from alternative position base
+				line = alt.Line + d
+				switch {
+				case alt.Column == 0:
+					// alternative column is unknown => relative column is unknown
+					column = 0
+				case d == 0:
+					// the alternative position base is on the current line
+					// => column is relative to alternative column
+					column = alt.Column + (offset - alt.Offset)
+				}
+			}
+		}
+	}
 	return filename, line, column
 }
 
+func searchLineInfos(a []lineInfo, x int) int {
+	i, j := 0, len(a)
+	for i < j {
+		h := i + (j-i)/2 // avoid overflow when computing h
+		if a[h].Offset <= x {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i - 1
+}
+
 func searchInts(a []int, x int) int {
 	i, j := 0, len(a)
 	for i < j {
@@ -8,7 +8,7 @@ import (
 
 // FileSet represents a set of source files.
 type FileSet struct {
-	base  int     // base offset for the next file
+	base  int64   // base offset for the next file
 	files []*File // list of files in the order added to the set
 	last  *File   // cache of last file looked up
 }
@@ -22,12 +22,13 @@ func NewFileSet() *FileSet {
 
 // Base returns the minimum base offset that must be provided to
 // [FileSet.AddFile] when adding the next file.
-func (s *FileSet) Base() int {
+func (s *FileSet) Base() int64 {
 	return s.base
 }
 
 // AddFile adds a new file in the file set.
-func (s *FileSet) AddFile(filename string, base, size int) *File {
+// If base is negative, the current [FileSet.Base] is used.
+func (s *FileSet) AddFile(filename string, base int64, size int) *File {
 	if base < 0 {
 		base = s.base
 	}
@@ -47,9 +48,9 @@ func (s *FileSet) AddFile(filename string, base, size int) *File {
 	}
 
 	// base >= s.base && size >= 0
-	base += size + 1 // +1 because EOF also has a position
+	base += int64(size) + 1 // +1 because EOF also has a position
 	if base < 0 {
-		panic("token.Pos offset overflow (> 2G of source code in file set)")
+		panic("token.Pos offset overflow")
 	}
 
 	// add the file to the file set
@@ -59,6 +60,64 @@ func (s *FileSet) AddFile(filename string, base, size int) *File {
 	return f
 }
 
+// RemoveFile removes a file from the set, so positions of the file
+// are not resolved anymore. Removing a file that is not in the set
+// has no effect.
+func (s *FileSet) RemoveFile(file *File) {
+	if s.last == file {
+		s.last = nil
+	}
+	if i := s.index(file); i >= 0 {
+		s.files = slices.Delete(s.files, i, i+1)
+	}
+}
+
+// ReplaceFile replaces the old file with a new file of the given name and size,
+// as done by servers that repeatedly parse new versions of the same file.
+//
+// The Pos range of the old file is reused if the new file fits before the
+// next file in the set; otherwise the new file is added as with [FileSet.AddFile].
+// Either way the file set does not grow for a file that keeps its size.
+// Positions of the old file must not be used after the call.
+// If old is not in the set, ReplaceFile panics.
+func (s *FileSet) ReplaceFile(old *File, filename string, size int) *File {
+	i := s.index(old)
+	if i < 0 {
+		panic(fmt.Sprintf("file %s is not in the file set", old.name))
+	}
+	if size < 0 {
+		panic(fmt.Sprintf("invalid size %d (should be >= 0)", size))
+	}
+
+	last := i == len(s.files)-1
+	end := old.base + int64(size) + 1 // +1 because EOF also has a position
+	if !last && end > s.files[i+1].base {
+		s.RemoveFile(old)
+		return s.AddFile(filename, -1, size)
+	}
+
+	f := &File{
+		name:  filename,
+		base:  old.base,
+		size:  size,
+		lines: []int{0},
+	}
+	s.files[i] = f
+	s.last = f
+	if last {
+		s.base = end
+	}
+	return f
+}
+
+// index returns the index of the file in s.files, or -1.
+func (s *FileSet) index(file *File) int {
+	if i := searchFiles(s.files, file.base); i >= 0 && s.files[i] == file {
+		return i
+	}
+	return -1
+}
+
 // File returns the file that contains the position p.
 // If no such file is found the result is nil.
 func (s *FileSet) File(p Pos) *File {
@@ -68,28 +127,36 @@ func (s *FileSet) File(p Pos) *File {
 	return s.file(p)
 }
 
-// Position converts a [Pos] p in the fileset into a Position value.
-func (s *FileSet) Position(p Pos) Position {
+// PositionFor converts a [Pos] p in the fileset into a Position value.
+// If adjusted is set, the position may be adjusted by position-altering
+// //line comments; otherwise those comments are ignored.
+func (s *FileSet) PositionFor(p Pos, adjusted bool) Position {
 	if p == NoPos {
 		return Position{}
 	}
 	if f := s.file(p); f != nil {
-		return f.position(p)
+		return f.position(p, adjusted)
 	}
 	return Position{}
 }
 
+// Position converts a [Pos] p in the fileset into a Position value.
+// Calling s.Position(p) is equivalent to calling s.PositionFor(p, true).
+func (s *FileSet) Position(p Pos) Position {
+	return s.PositionFor(p, true)
+}
+
 func (s *FileSet) file(p Pos) *File {
 	// common case: p is in last file.
-	if f := s.last; f != nil && f.base <= int(p) && int(p) <= f.base+f.size {
+	if f := s.last; f != nil && f.contains(p) {
 		return f
 	}
 
 	// p is not in last file - search all files
-	if i := searchFiles(s.files, int(p)); i >= 0 {
+	if i := searchFiles(s.files, int64(p)); i >= 0 {
 		f := s.files[i]
-		// f.base <= int(p) by definition of searchFiles
-		if int(p) <= f.base+f.size {
+		// f.base <= p by definition of searchFiles
+		if int64(p) <= f.base+int64(f.size) {
 			s.last = f
 			return f
 		}
@@ -97,8 +164,8 @@ func (s *FileSet) file(p Pos) *File {
 	return nil
 }
 
-func searchFiles(a []*File, x int) int {
-	i, found := slices.BinarySearchFunc(a, x, func(a *File, x int) int {
+func searchFiles(a []*File, x int64) int {
+	i, found := slices.BinarySearchFunc(a, x, func(a *File, x int64) int {
 		return cmp.Compare(a.base, x)
 	})
 	if !found {
@@ -0,0 +1,53 @@
+package token
+
+import "testing"
+
+func TestRemoveFile(t *testing.T) {
+	fset := NewFileSet()
+	a := fset.AddFile("a", -1, 10)
+	b := fset.AddFile("b", -1, 10)
+
+	pa, pb := a.Pos(5), b.Pos(5)
+	fset.RemoveFile(a)
+	fset.RemoveFile(a) // no-op
+
+	if f := fset.File(pa); f != nil {
+		t.Errorf("removed file %s is still found", f.Name())
+	}
+	if f := fset.File(pb); f != b {
+		t.Errorf("have file %v, want %s", f, b.Name())
+	}
+}
+
+func TestReplaceFile(t *testing.T) {
+	fset := NewFileSet()
+	a := fset.AddFile("a", -1, 10)
+	b := fset.AddFile("b", -1, 10)
+	base := fset.Base()
+
+	// same size: the range is reused.
+	a2 := fset.ReplaceFile(a, "a", 10)
+	if a2.Base() != a.Base() || fset.Base() != base {
+		t.Errorf("have base %d and set base %d, want %d and %d", a2.Base(), fset.Base(), a.Base(), base)
+	}
+
+	// grown file before another one: added at the end.
+	a3 := fset.ReplaceFile(a2, "a", 20)
+	if a3.Base() != base {
+		t.Errorf("have base %d, want %d", a3.Base(), base)
+	}
+	if f := fset.File(a2.Pos(1)); f != nil {
+		t.Errorf("replaced file %s is still found", f.Name())
+	}
+
+	// last file may grow in place.
+	a4 := fset.ReplaceFile(a3, "a", 100)
+	if a4.Base() != a3.Base() || fset.Base() != a3.Base()+101 {
+		t.Errorf("have base %d and set base %d, want %d and %d", a4.Base(), fset.Base(), a3.Base(), a3.Base()+101)
+	}
+
+	if f := fset.File(b.Pos(3)); f != b {
+		t.Errorf("have file %v, want %s", f, b.Name())
+	}
+	checkPos(t, "a4", fset.Position(a4.Pos(50)), Position{Filename: "a", Offset: 50, Line: 1, Column: 51})
+}
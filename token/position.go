@@ -6,7 +6,9 @@ import (
 )
 
 // Pos represents a position in the file set.
-type Pos int
+// It is a 64-bit value on all platforms, so a file set may hold
+// more than 2G of source code.
+type Pos int64
 
 // NoPos represents an invalid position.
 const NoPos Pos = 0
@@ -31,3 +31,63 @@ func checkPos(t testing.TB, msg string, have, want Position) {
 		t.Errorf("%s: have column = %d; want %d", msg, have.Column, want.Column)
 	}
 }
+
+func TestLargeFileSet(t *testing.T) {
+	const size = 1 << 30 // 3 files of 1G each, 3G of source
+
+	fset := NewFileSet()
+	for range 3 {
+		fset.AddFile("huge", -1, size)
+	}
+	f := fset.AddFile("small", -1, 10)
+	f.AddLine(5)
+
+	if want := int64(1 + 3*(size+1)); f.Base() != want {
+		t.Fatalf("have base %d, want %d", f.Base(), want)
+	}
+
+	p := f.Pos(7)
+	if fset.File(p) != f {
+		t.Fatalf("position %d is not found in file %s", p, f.Name())
+	}
+	checkPos(t, "small", fset.Position(p), Position{Filename: "small", Offset: 7, Line: 2, Column: 3})
+}
+
+func TestPositionFor(t *testing.T) {
+	src := []byte("foo\nbar\nbaz\nqux\n")
+
+	fset := NewFileSet()
+	f := fset.AddFile("foo", fset.Base(), len(src))
+	for i, ch := range src {
+		if ch == '\n' {
+			f.AddLine(i + 1)
+		}
+	}
+	// "bar" is generated from gen.st:10:5, "baz" onwards from gen.st:20 with unknown columns.
+	f.AddLineColumnInfo(4, "gen.st", 10, 5)
+	f.AddLineColumnInfo(8, "gen.st", 20, 0)
+	f.AddLineColumnInfo(6, "ignored.st", 1, 1) // out of order
+
+	testCases := []struct {
+		offs     int
+		adjusted string
+		plain    string
+	}{
+		{1, "foo:1:2", "foo:1:2"},
+		{5, "gen.st:10:6", "foo:2:2"},
+		{9, "gen.st:20", "foo:3:2"},
+		{13, "gen.st:21", "foo:4:2"},
+	}
+	for _, tc := range testCases {
+		p := f.Pos(tc.offs)
+		if have := fset.PositionFor(p, true).String(); have != tc.adjusted {
+			t.Errorf("offset %d: have adjusted %s, want %s", tc.offs, have, tc.adjusted)
+		}
+		if have := fset.PositionFor(p, false).String(); have != tc.plain {
+			t.Errorf("offset %d: have %s, want %s", tc.offs, have, tc.plain)
+		}
+		if have, want := f.Position(p), f.PositionFor(p, true); have != want {
+			t.Errorf("offset %d: Position %s differs from PositionFor %s", tc.offs, have, want)
+		}
+	}
+}
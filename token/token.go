@@ -27,18 +27,24 @@ const (
 	literalZ
 
 	// Operators and delimiters
+	//
+	// Every "**" is scanned as Pow, also where it stands for two '*',
+	// e.g. **p or **T; a parser must split it into two Mul there.
 	operatorA
 	Add    // +
 	Sub    // -
 	Mul    // *
 	Quo    // /
 	Rem    // %
+	Pow    // **
 	And    // &
 	Or     // |
 	Xor    // ^
 	AndNot // &^
 	Shl    // <<
 	Shr    // >>
+	Rotl   // <<<
+	Rotr   // >>>
 	Concat // ++
 
 	Assign       // =
@@ -47,12 +53,15 @@ const (
 	MulAssign    // *=
 	QuoAssign    // /=
 	RemAssign    // %=
+	PowAssign    // **=
 	AndAssign    // &=
 	OrAssign     // |=
 	XorAssign    // ^=
 	AndNotAssign // &^=
 	ShlAssign    // <<=
 	ShrAssign    // >>=
+	RotlAssign   // <<<=
+	RotrAssign   // >>>=
 	ConcatAssign // ++=
 
 	LogicAnd     // &&
@@ -71,6 +80,7 @@ const (
 	Period    // .
 	Colon     // :
 	Semicolon // ;
+	Question  // ?
 
 	LeftParen  // (
 	LeftBrack  // [
@@ -127,12 +137,15 @@ var tokens = [...]string{
 	Mul:    "*",
 	Quo:    "/",
 	Rem:    "%",
+	Pow:    "**",
 	And:    "&",
 	Or:     "|",
 	Xor:    "^",
 	AndNot: "&^",
 	Shl:    "<<",
 	Shr:    ">>",
+	Rotl:   "<<<",
+	Rotr:   ">>>",
 	Concat: "++",
 
 	Assign:       "=",
@@ -141,12 +154,15 @@ var tokens = [...]string{
 	MulAssign:    "*=",
 	QuoAssign:    "/=",
 	RemAssign:    "%=",
+	PowAssign:    "**=",
 	AndAssign:    "&=",
 	OrAssign:     "|=",
 	XorAssign:    "^=",
 	AndNotAssign: "&^=",
 	ShlAssign:    "<<=",
 	ShrAssign:    ">>=",
+	RotlAssign:   "<<<=",
+	RotrAssign:   ">>>=",
 	ConcatAssign: "++=",
 
 	LogicAnd:     "&&",
@@ -165,6 +181,7 @@ var tokens = [...]string{
 	Period:    ".",
 	Colon:     ":",
 	Semicolon: ";",
+	Question:  "?",
 
 	LeftParen:  "(",
 	LeftBrack:  "[",
@@ -211,8 +228,8 @@ func (tok Token) String() string {
 // indexing, and other operator and delimiter tokens.
 const (
 	LowestPrec  = 0 // non-operators
-	UnaryPrec   = 6
-	HighestPrec = 7
+	UnaryPrec   = 7
+	HighestPrec = 8
 )
 
 // Precedence returns the operator precedence of the binary operator.
@@ -227,13 +244,21 @@ func (tok Token) Precedence() int {
 		return 3
 	case Add, Sub, Or, Xor:
 		return 4
-	case Mul, Quo, Rem, Shl, Shr, And, AndNot, Concat:
+	case Mul, Quo, Rem, Shl, Shr, Rotl, Rotr, And, AndNot, Concat:
 		return 5
+	case Pow:
+		return 6
 	default:
 		return LowestPrec
 	}
 }
 
+// IsRightAssoc reports whether the binary operator is right-associative,
+// e.g. 2 ** 3 ** 2 is 2 ** (3 ** 2).
+func (tok Token) IsRightAssoc() bool {
+	return tok == Pow
+}
+
 // IsLiteral reports whether token corresponding to identifiers and basic type literals.
 func (tok Token) IsLiteral() bool {
 	return literalA < tok && tok < literalZ
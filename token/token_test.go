@@ -49,3 +49,18 @@ func TestIsIdentifier(t *testing.T) {
 		}
 	}
 }
+
+func TestPrecedence(t *testing.T) {
+	if !(Pow.Precedence() > Mul.Precedence() && Pow.Precedence() < UnaryPrec) {
+		t.Errorf("'**' precedence %d must be between '*' (%d) and unary (%d)", Pow.Precedence(), Mul.Precedence(), UnaryPrec)
+	}
+
+	for tok := Token(0); tok < tokenMax; tok++ {
+		if prec := tok.Precedence(); prec >= UnaryPrec {
+			t.Errorf("%s: binary precedence %d must be below unary %d", tok, prec, UnaryPrec)
+		}
+		if tok.IsRightAssoc() && tok.Precedence() == LowestPrec {
+			t.Errorf("%s: right-associative token must be a binary operator", tok)
+		}
+	}
+}